@@ -0,0 +1,22 @@
+package main
+
+import "testing"
+
+func TestCatchable(t *testing.T) {
+	config := &Config{}
+	if err := catchable(config, "pikachu"); err == nil {
+		t.Errorf("expected an error catching before exploring")
+	}
+	config.area = "eterna-city-area"
+	config.areaPokemon = []string{"psyduck", "magikarp"}
+	if err := catchable(config, "magikarp"); err != nil {
+		t.Error(err)
+	}
+	if err := catchable(config, "mewtwo"); err == nil {
+		t.Errorf("expected an error catching a pokemon not in %v", config.area)
+	}
+	config.freePlay = true
+	if err := catchable(config, "mewtwo"); err != nil {
+		t.Errorf("free play should allow any catch: %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/asrioth/pokedexcli/pokeCatch"
+)
+
+func commandEncounter(ctx context.Context, config *Config) error {
+	if err := returnToLocation(ctx, config); err != nil {
+		return err
+	}
+	if config.area == "" {
+		return errors.New("explore an area to look for wild pokemon")
+	}
+	slots, err := config.client.GetAreaEncounters(ctx, config.area)
+	if err != nil {
+		return err
+	}
+	slots = pokeCatch.VersionSlots(slots, config.version)
+	slots = pokeCatch.ConditionSlots(slots, pokeCatch.CurrentConditions(time.Now()))
+	methods := pokeCatch.EncounterMethods(slots)
+	if len(methods) == 0 {
+		if config.version != "" {
+			return fmt.Errorf("no wild pokemon live in %v in %v", config.area, config.version)
+		}
+		return fmt.Errorf("no wild pokemon live in %v", config.area)
+	}
+	method := methods[0]
+	if len(config.args) > 0 {
+		method = config.args[0]
+	} else if slices.Contains(methods, pokeCatch.DefaultMethod) {
+		method = pokeCatch.DefaultMethod
+	}
+	methodSlots := pokeCatch.MethodSlots(slots, method)
+	if config.version == "" {
+		methodSlots = pokeCatch.VersionSlots(methodSlots, config.catcher.PickVersion(methodSlots))
+	}
+	wild, ok := config.catcher.Encounter(methodSlots)
+	if !ok {
+		return fmt.Errorf("no pokemon can be found by %v in %v, try one of: %v", method, config.area, strings.Join(methods, ", "))
+	}
+	config.wild = &wild
+	fmt.Printf("A wild %v (level %v) appeared by %v!\n", wild.Name, wild.Level, wild.Method)
+	return nil
+}
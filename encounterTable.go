@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/asrioth/pokedexcli/pokeapi"
+)
+
+type encounterRow struct {
+	method     string
+	levels     string
+	chance     int
+	conditions string
+	versions   []string
+}
+
+// encounterRows merges a pokemon's slots that only differ by version, so an
+// area shared by several games doesn't repeat every row per game.
+func encounterRows(slots []pokeapi.EncounterSlot, pokemon string) []encounterRow {
+	var rows []encounterRow
+	for _, slot := range slots {
+		if slot.Pokemon != pokemon {
+			continue
+		}
+		levels := fmt.Sprint(slot.MinLevel)
+		if slot.MaxLevel > slot.MinLevel {
+			levels = fmt.Sprintf("%v-%v", slot.MinLevel, slot.MaxLevel)
+		}
+		conditions := strings.Join(slot.Conditions, ", ")
+		if conditions == "" {
+			conditions = "-"
+		}
+		row := encounterRow{method: slot.Method, levels: levels, chance: slot.Chance, conditions: conditions}
+		index := slices.IndexFunc(rows, func(other encounterRow) bool {
+			return other.method == row.method && other.levels == row.levels && other.chance == row.chance && other.conditions == row.conditions
+		})
+		if index < 0 {
+			rows = append(rows, row)
+			index = len(rows) - 1
+		}
+		if !slices.Contains(rows[index].versions, slot.Version) {
+			rows[index].versions = append(rows[index].versions, slot.Version)
+		}
+	}
+	return rows
+}
+
+func writeEncounterTable(w io.Writer, slots []pokeapi.EncounterSlot, pokemons []string) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "POKEMON\tMETHOD\tLEVELS\tCHANCE\tCONDITIONS\tVERSIONS")
+	for _, pokemon := range pokemons {
+		name := pokemon
+		for _, row := range encounterRows(slots, pokemon) {
+			fmt.Fprintf(table, "%v\t%v\t%v\t%v%%\t%v\t%v\n", name, row.method, row.levels, row.chance, row.conditions, strings.Join(row.versions, ", "))
+			name = ""
+		}
+	}
+	return table.Flush()
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/asrioth/pokedexcli/pokeapi"
+)
+
+func TestWriteEncounterTable(t *testing.T) {
+	slots := []pokeapi.EncounterSlot{
+		{Pokemon: "psyduck", Version: "diamond", Method: "surf", Chance: 90, MinLevel: 20, MaxLevel: 30},
+		{Pokemon: "psyduck", Version: "pearl", Method: "surf", Chance: 90, MinLevel: 20, MaxLevel: 30},
+		{Pokemon: "gyarados", Version: "diamond", Method: "good-rod", Chance: 40, MinLevel: 15, MaxLevel: 15, Conditions: []string{"time-night"}},
+	}
+	var table strings.Builder
+	if err := writeEncounterTable(&table, slots, []string{"psyduck", "gyarados"}); err != nil {
+		t.Error(err)
+		return
+	}
+	expected := []string{
+		"POKEMON   METHOD    LEVELS  CHANCE  CONDITIONS  VERSIONS",
+		"psyduck   surf      20-30   90%     -           diamond, pearl",
+		"gyarados  good-rod  15      40%     time-night  diamond",
+	}
+	actual := strings.Split(strings.TrimRight(table.String(), "\n"), "\n")
+	if len(actual) != len(expected) {
+		t.Errorf("actual table\n%v\ndid not match expected table\n%v", table.String(), strings.Join(expected, "\n"))
+		return
+	}
+	for index := range expected {
+		if actual[index] != expected[index] {
+			t.Errorf("actual row '%v' did not match expected row '%v'", actual[index], expected[index])
+		}
+	}
+}
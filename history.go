@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+func commandHistory(ctx context.Context, config *Config) error {
+	name := ""
+	result := ""
+	for _, arg := range config.args {
+		if arg == "caught" || arg == "escaped" {
+			result = arg
+		} else {
+			name = arg
+		}
+	}
+	history := config.pokedex.CatchHistory(name)
+	shown := 0
+	for _, event := range history {
+		if (result == "caught" && !event.Caught) || (result == "escaped" && event.Caught) {
+			continue
+		}
+		if shown == 0 {
+			fmt.Println("Catch history:")
+		}
+		shown++
+		outcome := "escaped"
+		if event.Caught {
+			outcome = "caught"
+		}
+		area := event.Area
+		if area == "" {
+			area = "unknown area"
+		}
+		name := event.Name
+		if event.Level > 0 {
+			name = fmt.Sprintf("%v (level %v)", event.Name, event.Level)
+		}
+		fmt.Printf(" - %v %v %v with a %v in %v (roll %.3f)\n", event.Time.Local().Format("2006-01-02 15:04:05"), name, outcome, event.Ball, area, event.Roll)
+	}
+	if shown == 0 {
+		if name != "" {
+			return fmt.Errorf("no catch attempts for %v yet", name)
+		}
+		return errors.New("no catch attempts yet")
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/asrioth/pokedexcli/pokeCatch"
+)
+
+func commandInventory(ctx context.Context, config *Config) error {
+	fmt.Println("Inventory:")
+	for _, ball := range pokeCatch.Balls {
+		fmt.Printf(" - %v: %v (x%v catch rate)\n", ball, config.pokedex.BallCount(ball), pokeCatch.BallModifiers[ball])
+	}
+	return nil
+}
+
+func commandRestock(ctx context.Context, config *Config) error {
+	added := config.pokedex.Restock()
+	if len(added) == 0 {
+		fmt.Println("Your bag is already stocked up")
+		return nil
+	}
+	fmt.Println("Restocked at the poke mart:")
+	for _, ball := range pokeCatch.Balls {
+		if added[ball] > 0 {
+			fmt.Printf(" - %v: +%v\n", ball, added[ball])
+		}
+	}
+	if err := config.pokedex.Save(); err != nil {
+		return fmt.Errorf("could not save your pokedex: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/asrioth/pokedexcli/pokedexData"
+)
+
+func TestCommandRestock(t *testing.T) {
+	dataDir := t.TempDir()
+	pokedex := pokedexData.NewPokeDex(dataDir)
+	pokedex.Inventory = map[string]int{}
+	config := &Config{pokedex: &pokedex}
+	runCommands(context.Background(), cleanInput("restock"), initializeCommands(), config)
+	if count := pokedex.BallCount("poke-ball"); count != 20 {
+		t.Errorf("actual poke-ball count %v did not match expected count 20", count)
+	}
+	saved := pokedexData.NewPokeDex(dataDir)
+	if err := saved.Load(); err != nil {
+		t.Error(err)
+		return
+	}
+	if count := saved.BallCount("ultra-ball"); count != 2 {
+		t.Errorf("restock not saved, ultra-ball count %v", count)
+	}
+}
@@ -2,109 +2,312 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"errors"
+	"flag"
 	"fmt"
-	"math/rand"
+	"net/http"
 	"os"
+	"os/signal"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
-	"github.com/asrioth/pokedexcli/pokeCache"
+	"github.com/asrioth/pokedexcli/pokeCatch"
+	"github.com/asrioth/pokedexcli/pokeDirs"
 	"github.com/asrioth/pokedexcli/pokeapi"
 	"github.com/asrioth/pokedexcli/pokedexData"
 )
 
 type Config struct {
-	Next     int
-	Previous int
+	Next     string
+	Previous string
 	args     []string
-	data     *pokeCache.Cache
-	pokedex  pokedexData.PokeDex
+	pokedex  *pokedexData.PokeDex
+	client   *pokeapi.Client
+	dataDir  string
+	profile  string
+	area     string
+	// areaPokemon are the species found by the last explore, the only ones
+	// that can be caught unless freePlay is set
+	areaPokemon []string
+	// wild is the pokemon met by the last encounter, until it is caught or
+	// another area is explored
+	wild *pokeCatch.WildPokemon
+	// version is the game explore and encounter are limited to, empty for
+	// every game
+	version  string
+	freePlay bool
+	catcher  *pokeCatch.Engine
 }
 
+const defaultBall = "poke-ball"
+
 type CliCommand struct {
 	name        string
 	description string
-	callback    func(*Config) error
-	config      *Config
+	callback    func(context.Context, *Config) error
+	minArgs     int
+	maxArgs     int
 }
 
 func initializeCommands() map[string]CliCommand {
-	pokedex := pokedexData.PokeDex{CaughtPokemon: make(map[string]pokedexData.Pokemon)}
-	pokedex.Load()
-	mapConfig := Config{0, 0, nil, pokeCache.NewCache(time.Second * 5), pokedex}
-	exploreArgs := make([]string, 1)
 	supportedCommands := map[string]CliCommand{
 		"exit": {
 			name:        "exit",
 			description: "Exit the Pokedex",
 			callback:    commandExit,
-			config:      &Config{0, 0, nil, nil, pokedex},
 		},
 		"help": {
-			name:        "exit",
+			name:        "help",
 			description: "Displays a help message",
 			callback:    commandHelp,
-			config:      &Config{0, 0, nil, nil, pokedex},
 		},
 		"map": {
 			name:        "map",
 			description: "Lists the next 20 location areas",
 			callback:    commandMap,
-			config:      &mapConfig,
 		},
 		"mapb": {
 			name:        "mapb",
 			description: "Lists the previous 20 location areas",
 			callback:    commandMapBack,
-			config:      &mapConfig,
+		},
+		"regions": {
+			name:        "regions",
+			description: "Lists the regions of the pokemon world",
+			callback:    commandRegions,
+		},
+		"locations": {
+			name:        "locations",
+			description: "Lists the locations in a region eg. locations kanto",
+			callback:    commandLocations,
+			minArgs:     1,
+			maxArgs:     1,
+		},
+		"areas": {
+			name:        "areas",
+			description: "Lists the areas of a location that can be explored eg. areas pallet-town",
+			callback:    commandAreas,
+			minArgs:     1,
+			maxArgs:     1,
+		},
+		"travel": {
+			name:        "travel",
+			description: "Travels to an area, where explore looks by default eg. travel eterna-city-area",
+			callback:    commandTravel,
+			minArgs:     1,
+			maxArgs:     1,
+		},
+		"where": {
+			name:        "where",
+			description: "Shows the area you are in",
+			callback:    commandWhere,
 		},
 		"explore": {
 			name:        "explore",
-			description: "Lists all pokemon in the area, takes an area name eg. explore canalave-city-area",
+			description: "Lists all pokemon in the area, takes an area name, or explores where you are, and optionally --versions to show the games each is found in or --detail for how each is encountered eg. explore canalave-city-area --detail",
 			callback:    commandExplore,
-			config:      &Config{0, 0, exploreArgs, nil, pokedex},
+			maxArgs:     3,
+		},
+		"version": {
+			name:        "version",
+			description: "Shows or sets the game version explore and encounter are limited to, all for every version eg. version platinum",
+			callback:    commandVersion,
+			maxArgs:     1,
+		},
+		"encounter": {
+			name:        "encounter",
+			description: "Looks for a wild pokemon in the explored area, optionally by an encounter method eg. encounter surf",
+			callback:    commandEncounter,
+			maxArgs:     1,
 		},
 		"catch": {
 			name:        "catch",
-			description: "Attemps to catch named pokemon. If successful adds it to pokeDex",
+			description: "Attemps to catch named pokemon found in the explored area, optionally with a ball from your inventory eg. catch pikachu great. If successful adds it to pokeDex",
 			callback:    commandCatch,
-			config:      &Config{0, 0, exploreArgs, nil, pokedex},
+			minArgs:     1,
+			maxArgs:     2,
 		},
 		"inspect": {
 			name:        "inspect",
 			description: "Displays pokemon data if user has attemted to catch the pokemon before",
 			callback:    commandInspect,
-			config:      &Config{0, 0, exploreArgs, nil, pokedex},
+			minArgs:     1,
+			maxArgs:     1,
 		},
 		"pokedex": {
 			name:        "pokedex",
 			description: "Lists the pokemon caught so far",
 			callback:    commandPokedex,
-			config:      &Config{0, 0, nil, nil, pokedex},
+		},
+		"inventory": {
+			name:        "inventory",
+			description: "Lists the balls you have left to throw",
+			callback:    commandInventory,
+		},
+		"restock": {
+			name:        "restock",
+			description: "Tops your balls back up to what you started with, apart from the one-off master ball",
+			callback:    commandRestock,
+		},
+		"history": {
+			name:        "history",
+			description: "Lists your catch attempts, optionally for one pokemon and only caught or escaped ones eg. history pikachu caught",
+			callback:    commandHistory,
+			maxArgs:     2,
+		},
+		"sync": {
+			name:        "sync",
+			description: "Downloads every location-area, pokemon, pokemon-species and type, or just those named, for use offline eg. sync pokemon",
+			callback:    commandSync,
+			maxArgs:     len(pokeapi.SyncResources),
+		},
+		"profile": {
+			name:        "profile",
+			description: "Manages trainer profiles: profile list, profile new <name>, profile switch <name>, profile delete <name>",
+			callback:    commandProfile,
+			minArgs:     1,
+			maxArgs:     2,
 		},
 	}
 	return supportedCommands
 }
 
-func catch(baseXp int) bool {
-	catchRate := float64(baseXp) / 644.0
-	catchChance := rand.Float64()
-	return catchChance >= catchRate
-}
-
 func main() {
+	baseUrl := flag.String("api-url", pokeapi.DefaultBaseUrl, "base url of the PokeAPI server")
+	timeout := flag.Duration("timeout", pokeapi.DefaultTimeout, "timeout for each PokeAPI request")
+	retries := flag.Int("retries", pokeapi.DefaultRetryPolicy.MaxAttempts, "attempts made for each PokeAPI request before giving up")
+	workers := flag.Int("workers", pokeapi.DefaultWorkers, "number of PokeAPI requests made at once")
+	rate := flag.Float64("rate", pokeapi.DefaultRequestsPerSecond, "maximum PokeAPI requests per second, 0 for no limit")
+	dataDirFlag := flag.String("data-dir", "", "directory the pokedex is saved in, defaults to $"+pokeDirs.DataDirEnv+" or $XDG_DATA_HOME/pokedexcli")
+	cacheDirFlag := flag.String("cache-dir", "", "directory PokeAPI responses are cached in, defaults to $"+pokeDirs.CacheDirEnv+" or $XDG_CACHE_HOME/pokedexcli")
+	seed := flag.Int64("seed", 0, "seed for catch rolls so a session can be replayed, 0 for a random seed")
+	freePlay := flag.Bool("free-play", false, "allow catching any pokemon from anywhere, not just those in the explored area")
+	offline := flag.Bool("offline", false, "only use cached PokeAPI data, see the sync command")
+	ttls := ttlFlag{}
+	flag.Var(ttls, "ttl", "how long cached PokeAPI data for a resource is used before it is refetched in the background eg. -ttl location-area=24h, repeatable, 0 to refetch on every use (default "+pokeapi.DefaultTTL.String()+")")
+	flag.Parse()
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	client := pokeapi.NewClient(*baseUrl, *timeout)
+	client.Retry.MaxAttempts = *retries
+	client.Workers = *workers
+	client.Limiter = pokeapi.NewRateLimiter(*rate)
+	client.Offline = *offline
+	dataDir, err := pokeDirs.DataDir(*dataDirFlag)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	cacheDir, err := pokeDirs.CacheDir(*cacheDirFlag)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	store, err := pokeapi.OpenStore(cacheDir)
+	if err != nil {
+		fmt.Printf("could not open the PokeAPI cache, continuing without it: %v\n", err)
+	} else {
+		store.TTLs = ttls
+	}
+	client.Cache = pokeapi.NewChainedCache(pokeapi.NewMemoryCache(ttls, pokeapi.DefaultMemoryEntries), store)
+	if err := pokedexData.MigrateSingleProfile(dataDir); err != nil {
+		fmt.Printf("could not move your pokedex into the default profile: %v\n", err)
+		os.Exit(1)
+	}
+	if err := pokedexData.MigrateLegacyPokedex(dataDir, pokedexData.LegacyPokedexPath); errors.Is(err, pokedexData.ErrLegacyPokedexKept) {
+		fmt.Printf("warning: %v\n", err)
+	} else if err != nil {
+		fmt.Printf("could not move your pokedex into the default profile: %v\n", err)
+		os.Exit(1)
+	}
+	profile, err := pokedexData.ActiveProfile(dataDir)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	pokedex, err := pokedexData.LoadProfile(dataDir, profile)
+	if errors.Is(err, pokedexData.ErrRestoredFromBackup) {
+		fmt.Printf("warning: %v\n", err)
+	} else if err != nil {
+		fmt.Printf("could not load your pokedex, fix or move %v to start a new one: %v\n", pokedex.Path(), err)
+		os.Exit(1)
+	}
+	config := &Config{
+		Next:     client.FirstAreaPageUrl(),
+		pokedex:  &pokedex,
+		client:   client,
+		dataDir:  dataDir,
+		profile:  profile,
+		catcher:  pokeCatch.NewEngine(*seed),
+		freePlay: *freePlay,
+	}
 	input := bufio.NewScanner(os.Stdin)
 	supportedCommands := initializeCommands()
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	canceller := &commandCanceller{}
+	go canceller.watch(interrupts, func() string { return prompt(config) })
 	for {
-		fmt.Print("Pokedex > ")
-		input.Scan()
+		fmt.Print(prompt(config))
+		if !input.Scan() {
+			fmt.Println()
+			commandExit(context.Background(), config)
+		}
 		words := cleanInput(input.Text())
-		runCommands(words, supportedCommands)
+		ctx := canceller.start()
+		runCommands(ctx, words, supportedCommands, config)
+		canceller.finish()
+	}
+}
+
+func prompt(config *Config) string {
+	if config.pokedex.Location != "" {
+		return fmt.Sprintf("Pokedex [%v @ %v] > ", config.profile, config.pokedex.Location)
+	}
+	return fmt.Sprintf("Pokedex [%v] > ", config.profile)
+}
+
+type commandCanceller struct {
+	cancel context.CancelFunc
+	lock   sync.Mutex
+}
+
+func (C *commandCanceller) start() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	C.lock.Lock()
+	C.cancel = cancel
+	C.lock.Unlock()
+	return ctx
+}
+
+func (C *commandCanceller) finish() {
+	C.lock.Lock()
+	if C.cancel != nil {
+		C.cancel()
+		C.cancel = nil
 	}
+	C.lock.Unlock()
 }
 
-func runCommands(words []string, supportedCommands map[string]CliCommand) {
+// watch cancels the running command on Ctrl-C instead of killing the REPL.
+func (C *commandCanceller) watch(interrupts <-chan os.Signal, prompt func() string) {
+	for range interrupts {
+		C.lock.Lock()
+		if C.cancel != nil {
+			C.cancel()
+			fmt.Println()
+		} else {
+			fmt.Print("\n" + prompt())
+		}
+		C.lock.Unlock()
+	}
+}
+
+func runCommands(ctx context.Context, words []string, supportedCommands map[string]CliCommand, config *Config) {
 	for i := 0; i < len(words); i++ {
 		word := words[i]
 		command, ok := supportedCommands[word]
@@ -112,29 +315,64 @@ func runCommands(words []string, supportedCommands map[string]CliCommand) {
 			fmt.Printf("%v not a valid command.\n All of input : %v must be valid commands or part of a valid command.\n", word, words)
 			break
 		}
-		if i+len(command.config.args) >= len(words) {
-			fmt.Printf("%v expects %v arguments and command has %v arguments.\n", word, len(command.config.args), len(words)-(i+1))
-			break
-		}
-		for argI := 0; argI < len(command.config.args); argI++ {
+		var args []string
+		for i+1 < len(words) && len(args) < command.maxArgs {
+			// optional arguments stop at the next command on the line
+			if _, isCommand := supportedCommands[words[i+1]]; isCommand && len(args) >= command.minArgs {
+				break
+			}
 			i++
-			command.config.args[argI] = words[i]
+			args = append(args, words[i])
+		}
+		if len(args) < command.minArgs {
+			fmt.Printf("%v expects %v arguments and command has %v arguments.\n", word, command.minArgs, len(args))
+			break
 		}
-		err := command.callback(command.config)
+		config.args = args
+		err := command.callback(ctx, config)
 		if err != nil {
-			fmt.Printf("command returned error: %v\n", err)
+			printCommandError(ctx, config, word, err)
 			break
 		}
 	}
 }
 
-func commandExit(config *Config) error {
+func printCommandError(ctx context.Context, config *Config, word string, err error) {
+	var statusErr *pokeapi.StatusError
+	var offlineErr *pokeapi.OfflineError
+	switch {
+	case errors.Is(err, context.Canceled):
+		fmt.Printf("%v cancelled\n", word)
+	case errors.Is(err, pokeapi.ErrOffline) && errors.As(err, &offlineErr):
+		fmt.Printf("the %v data you asked for isn't cached and you're offline, run sync while online to download it.\n", offlineErr.Resource)
+	case errors.Is(err, pokeapi.ErrNotFound) && errors.As(err, &statusErr):
+		fmt.Printf("no %v named %v was found.\n", statusErr.Resource, statusErr.Name)
+		suggestions, suggestErr := config.client.SuggestNames(ctx, statusErr.Resource, statusErr.Name, 3)
+		if suggestErr == nil && len(suggestions) > 0 {
+			fmt.Printf("did you mean: %v?\n", strings.Join(suggestions, ", "))
+		}
+	case errors.Is(err, pokeapi.ErrRateLimited) && errors.As(err, &statusErr):
+		if statusErr.RetryAfter > 0 {
+			fmt.Printf("the PokeAPI is rate limiting requests, try again in %v.\n", statusErr.RetryAfter)
+		} else {
+			fmt.Println("the PokeAPI is rate limiting requests, try again later.")
+		}
+	case errors.Is(err, pokeapi.ErrServer) && errors.As(err, &statusErr):
+		fmt.Printf("the PokeAPI is having trouble (%v %v), try again later.\n", statusErr.StatusCode, http.StatusText(statusErr.StatusCode))
+	case errors.Is(err, pokeapi.ErrDecode):
+		fmt.Printf("could not understand the PokeAPI response: %v\n", err)
+	default:
+		fmt.Printf("command returned error: %v\n", err)
+	}
+}
+
+func commandExit(ctx context.Context, config *Config) error {
 	fmt.Println("Closing the Pokedex... Goodbye!")
 	os.Exit(0)
 	return nil
 }
 
-func commandHelp(config *Config) error {
+func commandHelp(ctx context.Context, config *Config) error {
 	supportedCommands := initializeCommands()
 	fmt.Println("Welcome to the Pokedex!")
 	fmt.Printf("Usage:\n\n")
@@ -144,93 +382,190 @@ func commandHelp(config *Config) error {
 	return nil
 }
 
-func commandMap(config *Config) error {
-	var data []string
-	data = config.data.GetRange(config.Next+1, config.Next+20)
-	if data == nil {
-		mapStrings, err := pokeapi.GetMapStrings(config.Next, config.Next+19)
-		if err != nil {
-			return err
-		}
-		data = mapStrings
-		config.data.AddAll(config.Next+1, data)
+func commandMap(ctx context.Context, config *Config) error {
+	if config.Next == "" {
+		fmt.Println("you're on the last page")
+		return nil
+	}
+	return showAreaPage(ctx, config, config.Next)
+}
+
+func commandMapBack(ctx context.Context, config *Config) error {
+	if config.Previous == "" {
+		fmt.Println("you're on the first page")
+		return nil
 	}
+	return showAreaPage(ctx, config, config.Previous)
+}
 
-	for _, datum := range data {
-		fmt.Println(datum)
+func showAreaPage(ctx context.Context, config *Config, pageUrl string) error {
+	page, err := config.client.GetAreaPage(ctx, pageUrl)
+	if err != nil {
+		return err
 	}
-	config.Previous = config.Next
-	config.Next += 20
+	for _, name := range page.Names {
+		fmt.Println(name)
+	}
+	if page.Next == "" {
+		fmt.Println("that was the last page of location areas")
+	}
+	config.Next = page.Next
+	config.Previous = page.Previous
 	return nil
 }
 
-func commandMapBack(config *Config) error {
-	if config.Previous <= 0 {
-		fmt.Println("you're on the first page")
-		return nil
+// enterArea makes area the one catch and encounter work in, keeping only the
+// pokemon found in the session's version.
+func enterArea(ctx context.Context, config *Config, area string) ([]pokeapi.AreaPokemonVersions, error) {
+	pokemonVersions, err := config.client.GetAreaPokemonVersions(ctx, area)
+	if err != nil {
+		return nil, err
+	}
+	var pokemons []string
+	for _, pokemon := range pokemonVersions {
+		if config.version != "" && !slices.Contains(pokemon.Versions, config.version) {
+			continue
+		}
+		pokemons = append(pokemons, pokemon.Name)
+	}
+	config.area = area
+	config.areaPokemon = pokemons
+	config.wild = nil
+	return pokemonVersions, nil
+}
+
+func commandExplore(ctx context.Context, config *Config) error {
+	area := ""
+	showVersions := false
+	showDetail := false
+	for _, arg := range config.args {
+		if arg == "--versions" {
+			showVersions = true
+		} else if arg == "--detail" {
+			showDetail = true
+		} else {
+			area = arg
+		}
+	}
+	if area == "" {
+		area = config.pokedex.Location
 	}
-	var data []string
-	data = config.data.GetRange(config.Previous-19, config.Previous)
-	if data == nil {
-		mapStrings, err := pokeapi.GetMapStrings(config.Previous-20, config.Previous-1)
+	if area == "" {
+		return errors.New("explore needs an area name, or travel somewhere first")
+	}
+	fmt.Printf("Exploring %v...\n", area)
+	pokemonVersions, err := enterArea(ctx, config, area)
+	if err != nil {
+		return err
+	}
+	pokemons := config.areaPokemon
+	if config.version != "" {
+		if len(pokemons) == 0 {
+			fmt.Printf("No pokemon can be found here in %v\n", config.version)
+			return nil
+		}
+		fmt.Printf("Found Pokemon in %v:\n", config.version)
+	} else {
+		fmt.Println("Found Pokemon:")
+	}
+	if showDetail {
+		slots, err := config.client.GetAreaEncounters(ctx, area)
 		if err != nil {
 			return err
 		}
-		data = mapStrings
-		config.data.AddAll(config.Previous-19, data)
+		return writeEncounterTable(os.Stdout, pokeCatch.VersionSlots(slots, config.version), pokemons)
 	}
-	for _, datum := range data {
-		fmt.Println(datum)
+	for _, pokemon := range pokemonVersions {
+		if !slices.Contains(pokemons, pokemon.Name) {
+			continue
+		}
+		if showVersions {
+			fmt.Printf(" - %v (%v)\n", pokemon.Name, strings.Join(pokemon.Versions, ", "))
+		} else {
+			fmt.Printf(" - %v\n", pokemon.Name)
+		}
 	}
-	config.Next = config.Previous
-	config.Previous -= 20
 	return nil
 }
 
-func commandExplore(config *Config) error {
-	fmt.Printf("Exploring %v...\n", config.args[0])
-	pokemons, err := pokeapi.GetPokemonForArea(config.args[0])
-	if err != nil {
-		return err
+func catchable(config *Config, name string) error {
+	if config.freePlay {
+		return nil
+	}
+	if config.area == "" {
+		return errors.New("explore an area to find pokemon to catch")
 	}
-	fmt.Println("Found Pokemon:")
-	for _, pokemon := range pokemons {
-		fmt.Printf(" - %v\n", pokemon)
+	if !slices.Contains(config.areaPokemon, name) {
+		return fmt.Errorf("there is no %v in %v", name, config.area)
 	}
 	return nil
 }
 
-func commandCatch(config *Config) error {
+func commandCatch(ctx context.Context, config *Config) error {
 	name := config.args[0]
-	baseXp, err := pokeapi.GetPokemonBaseXp(name)
+	ball := defaultBall
+	if len(config.args) > 1 {
+		var err error
+		ball, err = pokeCatch.BallName(config.args[1])
+		if err != nil {
+			return err
+		}
+	}
+	if err := returnToLocation(ctx, config); err != nil {
+		return err
+	}
+	if err := catchable(config, name); err != nil {
+		return err
+	}
+	if config.pokedex.BallCount(ball) <= 0 {
+		return fmt.Errorf("you have no %v left, see inventory or restock", ball)
+	}
+	captureRate, err := config.client.GetPokemonCaptureRate(ctx, name)
 	if err != nil {
 		return err
 	}
-	fmt.Printf("Throwing a Pokeball at %v...\n", name)
-	if catch(baseXp) {
+	if err := config.pokedex.UseBall(ball); err != nil {
+		return err
+	}
+	fmt.Printf("Throwing a %v at %v...\n", ball, name)
+	result := config.catcher.Throw(pokeCatch.FullHealth(captureRate, pokeCatch.BallModifiers[ball]))
+	for shake := 0; shake < result.Shakes; shake++ {
+		fmt.Println("...shake...")
+	}
+	if result.Caught {
 		fmt.Printf("%v was caught!\n", name)
-		config.pokedex.Catch(name, true)
 	} else {
 		fmt.Printf("%v escaped!\n", name)
-		config.pokedex.Catch(name, false)
 	}
-	config.pokedex.Save()
+	event := pokedexData.CatchEvent{Caught: result.Caught, Area: config.area, Ball: ball, Roll: result.Roll}
+	if config.wild != nil && config.wild.Name == name {
+		event.Level = config.wild.Level
+		if result.Caught {
+			config.wild = nil
+		}
+	}
+	config.pokedex.Catch(name, event)
+	if err := config.pokedex.Save(); err != nil {
+		return fmt.Errorf("could not save your pokedex: %w", err)
+	}
 	return nil
 }
 
-func commandInspect(config *Config) error {
+func commandInspect(ctx context.Context, config *Config) error {
 	name := config.args[0]
 	pokemon, ok := config.pokedex.GetPokemon(name)
 	if !ok {
 		return errors.New("need to try catching a pokemon before inspecting it")
 	}
 	if pokemon.Description.Height == -1 {
-		pokemonDescription, err := pokeapi.GetPokemonStats(name)
+		pokemonDescription, err := config.client.GetPokemonStats(ctx, name)
 		if err != nil {
-			return nil
+			return err
 		}
 		config.pokedex.AddDescription(name, pokemonDescription)
-		config.pokedex.Save()
+		if err := config.pokedex.Save(); err != nil {
+			return fmt.Errorf("could not save your pokedex: %w", err)
+		}
 	}
 	pokemon, _ = config.pokedex.GetPokemon(name)
 	fmt.Printf("Name: %v\n", pokemon.Name)
@@ -270,7 +605,7 @@ func commandInspect(config *Config) error {
 	return nil
 }
 
-func commandPokedex(config *Config) error {
+func commandPokedex(ctx context.Context, config *Config) error {
 	fmt.Println("Your Pokedex:")
 	hasCaught := false
 	for _, pokemon := range config.pokedex.CaughtPokemon {
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+func commandRegions(ctx context.Context, config *Config) error {
+	regions, err := config.client.GetRegions(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Regions:")
+	for _, region := range regions {
+		fmt.Printf(" - %v\n", region)
+	}
+	return nil
+}
+
+func commandLocations(ctx context.Context, config *Config) error {
+	locations, err := config.client.GetRegionLocations(ctx, config.args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Locations in %v:\n", config.args[0])
+	for _, location := range locations {
+		fmt.Printf(" - %v\n", location)
+	}
+	return nil
+}
+
+func commandAreas(ctx context.Context, config *Config) error {
+	areas, err := config.client.GetLocationAreas(ctx, config.args[0])
+	if err != nil {
+		return err
+	}
+	if len(areas) == 0 {
+		return fmt.Errorf("%v has no areas to explore", config.args[0])
+	}
+	fmt.Printf("Areas in %v:\n", config.args[0])
+	for _, area := range areas {
+		fmt.Printf(" - %v\n", area)
+	}
+	return nil
+}
@@ -1,72 +1,247 @@
 package pokeCache
 
 import (
+	"container/list"
+	"context"
 	"sync"
 	"time"
 )
 
-type CacheEntry struct {
+type CacheEntry[K comparable, V any] struct {
+	key       K
 	createdAt time.Time
-	value     string
+	expiresAt time.Time
+	value     V
 }
 
-type Cache struct {
-	cache    map[int]CacheEntry
-	interval time.Duration
-	lock     sync.RWMutex
+func (C *CacheEntry[K, V]) fresh(now time.Time) bool {
+	return C.expiresAt.IsZero() || now.Before(C.expiresAt)
 }
 
-func NewCache(reapInterval time.Duration) *Cache {
-	cachMap := make(map[int]CacheEntry)
-	cache := Cache{cache: cachMap, interval: reapInterval}
-	go cache.reapLoop()
+// reapable entries have been stale for longer than they were fresh, so
+// GetOrLoad no longer serves them while refreshing.
+func (C *CacheEntry[K, V]) reapable(now time.Time) bool {
+	return !C.expiresAt.IsZero() && now.After(C.expiresAt.Add(C.expiresAt.Sub(C.createdAt)))
+}
+
+type Stats struct {
+	Hits      uint64
+	Misses    uint64
+	Stale     uint64
+	Evictions uint64
+	Expired   uint64
+}
+
+// Cache keeps values in memory until they have outlived their TTL or, once
+// maxEntries is reached, until they are the least recently used.
+type Cache[K comparable, V any] struct {
+	cache      map[K]*list.Element
+	order      *list.List
+	defaultTTL time.Duration
+	maxEntries int
+	onEvict    func(K, V)
+	refreshing map[K]bool
+	stats      Stats
+	lock       sync.Mutex
+	done       chan struct{}
+	closeOnce  sync.Once
+}
+
+// NewCache starts a cache whose entries live for defaultTTL unless added
+// with their own, and never expire when it is 0. The reaper runs every
+// defaultTTL. maxEntries of 0 leaves the cache unbounded.
+func NewCache[K comparable, V any](defaultTTL time.Duration, maxEntries int) *Cache[K, V] {
+	cache := Cache[K, V]{
+		cache:      make(map[K]*list.Element),
+		order:      list.New(),
+		defaultTTL: defaultTTL,
+		maxEntries: maxEntries,
+		refreshing: make(map[K]bool),
+		done:       make(chan struct{}),
+	}
+	if defaultTTL > 0 {
+		go cache.reapLoop()
+	}
 	return &cache
 }
 
-func (C *Cache) Add(key int, value string) {
+// OnEvict registers a callback for entries dropped by the LRU bound or the
+// reaper. It is called without the cache locked, so it may use the cache.
+func (C *Cache[K, V]) OnEvict(onEvict func(K, V)) {
+	C.lock.Lock()
+	C.onEvict = onEvict
+	C.lock.Unlock()
+}
+
+// Add caches the value for ttl, or the cache's default TTL when ttl is 0.
+func (C *Cache[K, V]) Add(key K, value V, ttl time.Duration) {
+	now := time.Now()
+	if ttl == 0 {
+		ttl = C.defaultTTL
+	}
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = now.Add(ttl)
+	}
+	C.lock.Lock()
+	if element, ok := C.cache[key]; ok {
+		entry := element.Value.(*CacheEntry[K, V])
+		entry.value = value
+		entry.createdAt = now
+		entry.expiresAt = expiresAt
+		C.order.MoveToFront(element)
+		C.lock.Unlock()
+		return
+	}
+	C.cache[key] = C.order.PushFront(&CacheEntry[K, V]{key, now, expiresAt, value})
+	var evicted []*CacheEntry[K, V]
+	for C.maxEntries > 0 && C.order.Len() > C.maxEntries {
+		evicted = append(evicted, C.removeElement(C.order.Back()))
+		C.stats.Evictions++
+	}
+	onEvict := C.onEvict
+	C.lock.Unlock()
+	notifyEvicted(onEvict, evicted)
+}
+
+// Get only returns fresh values, a stale entry is a miss.
+func (C *Cache[K, V]) Get(key K) (V, bool) {
+	C.lock.Lock()
+	defer C.lock.Unlock()
+	element, ok := C.cache[key]
+	if !ok || !element.Value.(*CacheEntry[K, V]).fresh(time.Now()) {
+		C.stats.Misses++
+		var empty V
+		return empty, false
+	}
+	C.stats.Hits++
+	C.order.MoveToFront(element)
+	return element.Value.(*CacheEntry[K, V]).value, true
+}
+
+// Has reports whether the key has a fresh value, without counting as a hit or
+// a miss or making the entry recently used.
+func (C *Cache[K, V]) Has(key K) bool {
 	C.lock.Lock()
-	cacheEntry := CacheEntry{time.Now(), value}
-	C.cache[key] = cacheEntry
+	defer C.lock.Unlock()
+	element, ok := C.cache[key]
+	return ok && element.Value.(*CacheEntry[K, V]).fresh(time.Now())
+}
+
+// GetOrLoad returns the cached value, loading and caching it for ttl when
+// missing. A stale value is returned as is while it is reloaded in the
+// background, with ctx's values but not its cancellation so the refresh
+// outlives the caller. A reapable value is dropped and loaded like a missing
+// one, even when no reaper runs.
+func (C *Cache[K, V]) GetOrLoad(ctx context.Context, key K, ttl time.Duration, loader func(context.Context) (V, error)) (V, error) {
+	now := time.Now()
+	C.lock.Lock()
+	var evicted []*CacheEntry[K, V]
+	if element, ok := C.cache[key]; ok && element.Value.(*CacheEntry[K, V]).reapable(now) {
+		evicted = append(evicted, C.removeElement(element))
+		C.stats.Expired++
+	} else if ok {
+		entry := element.Value.(*CacheEntry[K, V])
+		value := entry.value
+		C.order.MoveToFront(element)
+		if entry.fresh(now) {
+			C.stats.Hits++
+			C.lock.Unlock()
+			return value, nil
+		}
+		C.stats.Stale++
+		if !C.refreshing[key] {
+			C.refreshing[key] = true
+			go C.refresh(context.WithoutCancel(ctx), key, ttl, loader)
+		}
+		C.lock.Unlock()
+		return value, nil
+	}
+	C.stats.Misses++
+	onEvict := C.onEvict
 	C.lock.Unlock()
+	notifyEvicted(onEvict, evicted)
+	value, err := loader(ctx)
+	if err != nil {
+		return value, err
+	}
+	C.Add(key, value, ttl)
+	return value, nil
 }
 
-func (C *Cache) AddAll(firstId int, values []string) {
-	for index, value := range values {
-		C.Add(firstId+index, value)
+// refresh keeps serving the stale value if the reload fails, the next
+// GetOrLoad tries again.
+func (C *Cache[K, V]) refresh(ctx context.Context, key K, ttl time.Duration, loader func(context.Context) (V, error)) {
+	value, err := loader(ctx)
+	if err == nil {
+		C.Add(key, value, ttl)
 	}
+	C.lock.Lock()
+	delete(C.refreshing, key)
+	C.lock.Unlock()
 }
 
-func (C *Cache) Get(key int) (string, bool) {
-	C.lock.RLock()
-	defer C.lock.RUnlock()
-	value, ok := C.cache[key]
-	return value.value, ok
+func (C *Cache[K, V]) Len() int {
+	C.lock.Lock()
+	defer C.lock.Unlock()
+	return C.order.Len()
 }
 
-func (C *Cache) GetRange(firstId, lastId int) []string {
-	data := make([]string, lastId-firstId+1)
-	C.lock.RLock()
-	defer C.lock.RUnlock()
-	for id := firstId; id < lastId+1; id++ {
-		datum, ok := C.cache[id]
-		if !ok {
-			return nil
+func (C *Cache[K, V]) Stats() Stats {
+	C.lock.Lock()
+	defer C.lock.Unlock()
+	return C.stats
+}
+
+// Close stops the reaper. The cache can still be used afterwards, stale
+// entries just stay until evicted.
+func (C *Cache[K, V]) Close() {
+	C.closeOnce.Do(func() {
+		close(C.done)
+	})
+}
+
+func (C *Cache[K, V]) removeElement(element *list.Element) *CacheEntry[K, V] {
+	entry := C.order.Remove(element).(*CacheEntry[K, V])
+	delete(C.cache, entry.key)
+	return entry
+}
+
+func notifyEvicted[K comparable, V any](onEvict func(K, V), evicted []*CacheEntry[K, V]) {
+	if onEvict == nil {
+		return
+	}
+	for _, entry := range evicted {
+		onEvict(entry.key, entry.value)
+	}
+}
+
+func (C *Cache[K, V]) reap() {
+	now := time.Now()
+	C.lock.Lock()
+	var evicted []*CacheEntry[K, V]
+	for element := C.order.Front(); element != nil; {
+		next := element.Next()
+		if element.Value.(*CacheEntry[K, V]).reapable(now) {
+			evicted = append(evicted, C.removeElement(element))
+			C.stats.Expired++
 		}
-		data[id-firstId] = datum.value
+		element = next
 	}
-	return data
+	onEvict := C.onEvict
+	C.lock.Unlock()
+	notifyEvicted(onEvict, evicted)
 }
 
-func (C *Cache) reapLoop() {
-	ticker := time.NewTicker(C.interval)
+func (C *Cache[K, V]) reapLoop() {
+	ticker := time.NewTicker(C.defaultTTL)
+	defer ticker.Stop()
 	for {
-		<-ticker.C
-		C.lock.Lock()
-		for key, entry := range C.cache {
-			if time.Since(entry.createdAt) > C.interval {
-				delete(C.cache, key)
-			}
+		select {
+		case <-ticker.C:
+			C.reap()
+		case <-C.done:
+			return
 		}
-		C.lock.Unlock()
 	}
 }
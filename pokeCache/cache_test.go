@@ -1,6 +1,8 @@
 package pokeCache
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -24,8 +26,9 @@ func TestAddGet(t *testing.T) {
 
 	for i, c := range cases {
 		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
-			cache := NewCache(interval)
-			cache.Add(c.key, c.val)
+			cache := NewCache[int, string](interval, 0)
+			defer cache.Close()
+			cache.Add(c.key, c.val, 0)
 			val, ok := cache.Get(c.key)
 			if !ok {
 				t.Errorf("expected to find key")
@@ -41,9 +44,9 @@ func TestAddGet(t *testing.T) {
 
 func TestReapLoop(t *testing.T) {
 	const baseTime = 5 * time.Millisecond
-	const waitTime = baseTime + 5*time.Millisecond
-	cache := NewCache(baseTime)
-	cache.Add(1, "test1")
+	cache := NewCache[int, string](baseTime, 0)
+	defer cache.Close()
+	cache.Add(1, "test1", 0)
 
 	_, ok := cache.Get(1)
 	if !ok {
@@ -51,11 +54,197 @@ func TestReapLoop(t *testing.T) {
 		return
 	}
 
-	time.Sleep(waitTime)
+	deadline := time.Now().Add(time.Second)
+	for cache.Len() != 0 && time.Now().Before(deadline) {
+		time.Sleep(baseTime)
+	}
+	if cache.Len() != 0 {
+		t.Errorf("expected the reaper to remove the key")
+	}
+	if stats := cache.Stats(); stats.Expired != 1 {
+		t.Errorf("actual expired count %v did not match expected count 1", stats.Expired)
+	}
+}
 
-	_, ok = cache.Get(1)
-	if ok {
-		t.Errorf("expected to not find key")
-		return
+func TestLRUEviction(t *testing.T) {
+	cache := NewCache[string, int](0, 2)
+	var evicted []string
+	cache.OnEvict(func(key string, value int) {
+		evicted = append(evicted, key)
+	})
+	cache.Add("pikachu", 25, 0)
+	cache.Add("squirtle", 7, 0)
+	cache.Get("pikachu")
+	cache.Add("bulbasaur", 1, 0)
+	if _, ok := cache.Get("squirtle"); ok {
+		t.Errorf("expected least recently used key to be evicted")
+	}
+	if _, ok := cache.Get("pikachu"); !ok {
+		t.Errorf("expected recently used key to be kept")
+	}
+	if cache.Len() != 2 {
+		t.Errorf("actual length %v did not match expected length 2", cache.Len())
+	}
+	if len(evicted) != 1 || evicted[0] != "squirtle" {
+		t.Errorf("actual evicted keys %v did not match expected keys [squirtle]", evicted)
+	}
+}
+
+func TestStats(t *testing.T) {
+	cache := NewCache[int, string](0, 1)
+	cache.Add(1, "test1", 0)
+	cache.Get(1)
+	cache.Get(2)
+	cache.Add(2, "test2", 0)
+	expected := Stats{Hits: 1, Misses: 1, Evictions: 1}
+	if stats := cache.Stats(); stats != expected {
+		t.Errorf("actual stats %+v did not match expected stats %+v", stats, expected)
+	}
+}
+
+func TestHas(t *testing.T) {
+	cache := NewCache[int, string](0, 2)
+	cache.Add(1, "test1", 0)
+	cache.Add(2, "test2", 0)
+	cache.Add(3, "expired", time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	if !cache.Has(2) || cache.Has(3) || cache.Has(4) {
+		t.Errorf("actual Has results did not match the fresh entries")
+	}
+	if stats := cache.Stats(); stats.Hits != 0 || stats.Misses != 0 {
+		t.Errorf("expected Has to leave stats alone, got %+v", stats)
+	}
+}
+
+func TestReapCallback(t *testing.T) {
+	const baseTime = 5 * time.Millisecond
+	cache := NewCache[int, string](baseTime, 0)
+	defer cache.Close()
+	evicted := make(chan int, 1)
+	cache.OnEvict(func(key int, value string) {
+		evicted <- key
+	})
+	cache.Add(1, "test1", 0)
+	select {
+	case key := <-evicted:
+		if key != 1 {
+			t.Errorf("actual evicted key %v did not match expected key 1", key)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("expected reaped key to be passed to the callback")
+	}
+	if stats := cache.Stats(); stats.Expired != 1 {
+		t.Errorf("actual expired count %v did not match expected count 1", stats.Expired)
+	}
+}
+
+func TestClose(t *testing.T) {
+	const baseTime = 5 * time.Millisecond
+	cache := NewCache[int, string](baseTime, 0)
+	cache.Close()
+	cache.Close()
+	cache.Add(1, "test1", 0)
+	time.Sleep(4 * baseTime)
+	if cache.Len() != 1 {
+		t.Errorf("expected a closed cache to stop reaping")
+	}
+}
+
+func TestEntryTTL(t *testing.T) {
+	const baseTime = 5 * time.Millisecond
+	cache := NewCache[int, string](time.Hour, 0)
+	defer cache.Close()
+	cache.Add(1, "short", baseTime)
+	cache.Add(2, "default", 0)
+	time.Sleep(2 * baseTime)
+	if _, ok := cache.Get(1); ok {
+		t.Errorf("expected entry past its own ttl to miss")
+	}
+	if _, ok := cache.Get(2); !ok {
+		t.Errorf("expected entry with the default ttl to hit")
+	}
+}
+
+func TestGetOrLoad(t *testing.T) {
+	cache := NewCache[string, int](0, 0)
+	loads := 0
+	loader := func(ctx context.Context) (int, error) {
+		loads++
+		return loads, nil
+	}
+	value, err := cache.GetOrLoad(context.Background(), "pikachu", time.Hour, loader)
+	if err != nil || value != 1 {
+		t.Errorf("actual loaded value %v (%v) did not match expected value 1", value, err)
+	}
+	value, err = cache.GetOrLoad(context.Background(), "pikachu", time.Hour, loader)
+	if err != nil || value != 1 || loads != 1 {
+		t.Errorf("expected fresh value to be served without loading, got %v after %v loads", value, loads)
+	}
+	_, err = cache.GetOrLoad(context.Background(), "squirtle", time.Hour, func(ctx context.Context) (int, error) {
+		return 0, errors.New("offline")
+	})
+	if err == nil {
+		t.Errorf("expected loader error to be returned")
+	}
+	if _, ok := cache.Get("squirtle"); ok {
+		t.Errorf("expected failed load not to be cached")
+	}
+}
+
+func TestGetOrLoadStale(t *testing.T) {
+	const baseTime = 50 * time.Millisecond
+	cache := NewCache[string, string](0, 0)
+	cache.Add("pikachu", "stale", baseTime)
+	time.Sleep(baseTime + baseTime/5)
+	refreshed := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	value, err := cache.GetOrLoad(ctx, "pikachu", time.Hour, func(ctx context.Context) (string, error) {
+		defer close(refreshed)
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		return "fresh", nil
+	})
+	cancel()
+	if err != nil || value != "stale" {
+		t.Errorf("actual value '%v' (%v) did not match expected stale value", value, err)
+	}
+	select {
+	case <-refreshed:
+	case <-time.After(time.Second):
+		t.Fatal("expected stale value to be refreshed in the background")
+	}
+	deadline := time.Now().Add(time.Second)
+	for {
+		if value, ok := cache.Get("pikachu"); ok {
+			if value != "fresh" {
+				t.Errorf("actual refreshed value '%v' did not match expected value 'fresh'", value)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Errorf("refreshed value was never cached")
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if stats := cache.Stats(); stats.Stale != 1 {
+		t.Errorf("actual stale count %v did not match expected count 1", stats.Stale)
+	}
+}
+
+func TestGetOrLoadReapable(t *testing.T) {
+	const baseTime = 5 * time.Millisecond
+	cache := NewCache[string, string](0, 0)
+	cache.Add("pikachu", "ancient", baseTime)
+	time.Sleep(3 * baseTime)
+	value, err := cache.GetOrLoad(context.Background(), "pikachu", time.Hour, func(ctx context.Context) (string, error) {
+		return "fresh", nil
+	})
+	if err != nil || value != "fresh" {
+		t.Errorf("actual value '%v' (%v) did not match expected value 'fresh' for a reapable entry", value, err)
+	}
+	if stats := cache.Stats(); stats.Stale != 0 || stats.Expired != 1 {
+		t.Errorf("actual stats %+v did not count the reapable entry as expired", stats)
 	}
 }
@@ -0,0 +1,31 @@
+package pokeCatch
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Balls lists the balls that can be thrown, cheapest first.
+var Balls = []string{"poke-ball", "great-ball", "ultra-ball", "master-ball"}
+
+// BallModifiers are the games' ball bonuses. The master ball's is large
+// enough to push any species' capture rate past MaxCaptureRate.
+var BallModifiers = map[string]float64{
+	"poke-ball":   1,
+	"great-ball":  1.5,
+	"ultra-ball":  2,
+	"master-ball": 255,
+}
+
+// BallName accepts a ball by its PokeAPI item name or its short name, so
+// great and great-ball are the same ball.
+func BallName(ball string) (string, error) {
+	ball = strings.ToLower(ball)
+	if !strings.HasSuffix(ball, "-ball") {
+		ball += "-ball"
+	}
+	if _, ok := BallModifiers[ball]; !ok {
+		return "", fmt.Errorf("unknown ball %v, try one of %v", ball, strings.Join(Balls, ", "))
+	}
+	return ball, nil
+}
@@ -0,0 +1,30 @@
+package pokeCatch
+
+import "testing"
+
+func TestBallName(t *testing.T) {
+	cases := map[string]string{
+		"great":       "great-ball",
+		"Ultra-Ball":  "ultra-ball",
+		"master-ball": "master-ball",
+	}
+	for input, expected := range cases {
+		actual, err := BallName(input)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if actual != expected {
+			t.Errorf("actual ball %v did not match expected ball %v", actual, expected)
+		}
+	}
+	if _, err := BallName("dusk"); err == nil {
+		t.Errorf("expected an error for an unknown ball")
+	}
+}
+
+func TestMasterBallAlwaysCatches(t *testing.T) {
+	if chance := CatchChance(FullHealth(3, BallModifiers["master-ball"])); chance != 1 {
+		t.Errorf("actual master ball chance %v did not match expected chance 1", chance)
+	}
+}
@@ -0,0 +1,143 @@
+package pokeCatch
+
+import (
+	"slices"
+	"time"
+
+	"github.com/asrioth/pokedexcli/pokeapi"
+)
+
+// DefaultMethod is walking in tall grass, the encounter most areas have.
+const DefaultMethod = "walk"
+
+type WildPokemon struct {
+	Name   string
+	Level  int
+	Method string
+}
+
+// EncounterMethods lists the methods the slots can be met by, in the order
+// they first appear.
+func EncounterMethods(slots []pokeapi.EncounterSlot) []string {
+	var methods []string
+	for _, slot := range slots {
+		if !slices.Contains(methods, slot.Method) {
+			methods = append(methods, slot.Method)
+		}
+	}
+	return methods
+}
+
+func MethodSlots(slots []pokeapi.EncounterSlot, method string) []pokeapi.EncounterSlot {
+	var methodSlots []pokeapi.EncounterSlot
+	for _, slot := range slots {
+		if slot.Method == method {
+			methodSlots = append(methodSlots, slot)
+		}
+	}
+	return methodSlots
+}
+
+// VersionSlots narrows the slots to one game version, or leaves them all when
+// version is empty.
+func VersionSlots(slots []pokeapi.EncounterSlot, version string) []pokeapi.EncounterSlot {
+	if version == "" {
+		return slots
+	}
+	var versionSlots []pokeapi.EncounterSlot
+	for _, slot := range slots {
+		if slot.Version == version {
+			versionSlots = append(versionSlots, slot)
+		}
+	}
+	return versionSlots
+}
+
+// EncounterVersions lists the game versions the slots are from, in the order
+// they first appear.
+func EncounterVersions(slots []pokeapi.EncounterSlot) []string {
+	var versions []string
+	for _, slot := range slots {
+		if !slices.Contains(versions, slot.Version) {
+			versions = append(versions, slot.Version)
+		}
+	}
+	return versions
+}
+
+// PickVersion picks one of the slots' versions at random, for narrowing the
+// slots to when no version is set.
+func (E *Engine) PickVersion(slots []pokeapi.EncounterSlot) string {
+	versions := EncounterVersions(slots)
+	if len(versions) == 0 {
+		return ""
+	}
+	return versions[E.rng.Intn(len(versions))]
+}
+
+// DefaultConditions are the encounter conditions that hold when nothing out
+// of the ordinary is going on: no swarm, the radar and radio off and no game
+// in the DS's second slot.
+var DefaultConditions = []string{"swarm-no", "radar-off", "radio-off", "slot2-none"}
+
+var seasons = []string{"season-spring", "season-summer", "season-autumn", "season-winter"}
+
+// CurrentConditions are DefaultConditions plus the time of day and the
+// season at now. Like Black and White, seasons change every month.
+func CurrentConditions(now time.Time) []string {
+	timeOfDay := "time-night"
+	if hour := now.Hour(); hour >= 4 && hour < 10 {
+		timeOfDay = "time-morning"
+	} else if hour >= 10 && hour < 20 {
+		timeOfDay = "time-day"
+	}
+	season := seasons[(int(now.Month())-1)%len(seasons)]
+	return append(slices.Clone(DefaultConditions), timeOfDay, season)
+}
+
+// ConditionSlots narrows the slots to those whose conditions all hold. The
+// PokeAPI lists swarm, radar and time of day slots alongside the ordinary
+// ones, so without this they would be met as often as any other.
+func ConditionSlots(slots []pokeapi.EncounterSlot, conditions []string) []pokeapi.EncounterSlot {
+	var conditionSlots []pokeapi.EncounterSlot
+	for _, slot := range slots {
+		holds := true
+		for _, condition := range slot.Conditions {
+			if !slices.Contains(conditions, condition) {
+				holds = false
+				break
+			}
+		}
+		if holds {
+			conditionSlots = append(conditionSlots, slot)
+		}
+	}
+	return conditionSlots
+}
+
+// Encounter picks a slot weighted by its chance and a level in the slot's
+// range. The chances are only weights among the slots given, so callers
+// should narrow them to one method, one version, see PickVersion, and the
+// conditions that hold, see ConditionSlots, first.
+func (E *Engine) Encounter(slots []pokeapi.EncounterSlot) (WildPokemon, bool) {
+	total := 0
+	for _, slot := range slots {
+		total += max(slot.Chance, 0)
+	}
+	if total == 0 {
+		return WildPokemon{}, false
+	}
+	roll := E.rng.Intn(total)
+	for _, slot := range slots {
+		if roll >= max(slot.Chance, 0) {
+			roll -= max(slot.Chance, 0)
+			continue
+		}
+		level := slot.MinLevel
+		if slot.MaxLevel > slot.MinLevel {
+			level += E.rng.Intn(slot.MaxLevel - slot.MinLevel + 1)
+		}
+		return WildPokemon{Name: slot.Pokemon, Level: level, Method: slot.Method}, true
+	}
+	return WildPokemon{}, false
+}
@@ -0,0 +1,123 @@
+package pokeCatch
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/asrioth/pokedexcli/pokeapi"
+)
+
+var testSlots = []pokeapi.EncounterSlot{
+	{Pokemon: "psyduck", Method: "surf", Chance: 90, MinLevel: 20, MaxLevel: 30},
+	{Pokemon: "golduck", Method: "surf", Chance: 10, MinLevel: 20, MaxLevel: 40},
+	{Pokemon: "magikarp", Method: "old-rod", Chance: 100, MinLevel: 3, MaxLevel: 3},
+}
+
+func TestEncounterMethods(t *testing.T) {
+	methods := EncounterMethods(testSlots)
+	if len(methods) != 2 || methods[0] != "surf" || methods[1] != "old-rod" {
+		t.Errorf("actual methods %v did not match expected methods [surf old-rod]", methods)
+	}
+}
+
+func TestEncounterWeights(t *testing.T) {
+	engine := NewEngine(7)
+	slots := MethodSlots(testSlots, "surf")
+	counts := map[string]int{}
+	throws := 20000
+	for i := 0; i < throws; i++ {
+		wild, ok := engine.Encounter(slots)
+		if !ok {
+			t.Fatal("no pokemon encountered")
+		}
+		if wild.Level < 20 || (wild.Name == "psyduck" && wild.Level > 30) || wild.Level > 40 {
+			t.Errorf("level %v out of range for %v", wild.Level, wild.Name)
+		}
+		counts[wild.Name]++
+	}
+	if fraction := float64(counts["golduck"]) / float64(throws); math.Abs(fraction-0.1) > 0.01 {
+		t.Errorf("actual golduck fraction %.3f did not match expected fraction 0.1", fraction)
+	}
+	if counts["magikarp"] != 0 {
+		t.Errorf("old-rod pokemon encountered while surfing")
+	}
+}
+
+func TestEncounterPickedVersionWeights(t *testing.T) {
+	// pearl's chances add up to more than diamond's, so rolling across both
+	// versions would only find pikachu a third of the time.
+	slots := []pokeapi.EncounterSlot{
+		{Pokemon: "pikachu", Method: "walk", Version: "diamond", Chance: 100, MinLevel: 5, MaxLevel: 5},
+		{Pokemon: "zubat", Method: "walk", Version: "pearl", Chance: 100, MinLevel: 5, MaxLevel: 5},
+		{Pokemon: "geodude", Method: "walk", Version: "pearl", Chance: 100, MinLevel: 5, MaxLevel: 5},
+	}
+	engine := NewEngine(7)
+	counts := map[string]int{}
+	throws := 20000
+	for i := 0; i < throws; i++ {
+		version := engine.PickVersion(slots)
+		wild, ok := engine.Encounter(VersionSlots(slots, version))
+		if !ok {
+			t.Fatal("no pokemon encountered")
+		}
+		counts[wild.Name]++
+	}
+	if fraction := float64(counts["pikachu"]) / float64(throws); math.Abs(fraction-0.5) > 0.02 {
+		t.Errorf("actual pikachu fraction %.3f did not match expected fraction 0.5", fraction)
+	}
+	if engine.PickVersion(nil) != "" {
+		t.Errorf("expected no version picked from no slots")
+	}
+}
+
+func TestConditionSlots(t *testing.T) {
+	slots := []pokeapi.EncounterSlot{
+		{Pokemon: "bidoof", Method: "walk", Chance: 40, MinLevel: 3, MaxLevel: 3},
+		{Pokemon: "starly", Method: "walk", Chance: 30, MinLevel: 3, MaxLevel: 3, Conditions: []string{"time-day"}},
+		{Pokemon: "kricketot", Method: "walk", Chance: 30, MinLevel: 3, MaxLevel: 3, Conditions: []string{"time-night"}},
+		{Pokemon: "doduo", Method: "walk", Chance: 20, MinLevel: 3, MaxLevel: 3, Conditions: []string{"swarm-yes"}},
+		{Pokemon: "bidoof", Method: "walk", Chance: 20, MinLevel: 3, MaxLevel: 3, Conditions: []string{"swarm-no"}},
+		{Pokemon: "nidoran-f", Method: "walk", Chance: 10, MinLevel: 3, MaxLevel: 3, Conditions: []string{"radar-on"}},
+	}
+	noon := time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC)
+	conditionSlots := ConditionSlots(slots, CurrentConditions(noon))
+	var names []string
+	for _, slot := range conditionSlots {
+		names = append(names, slot.Pokemon)
+	}
+	if !slices.Equal(names, []string{"bidoof", "starly", "bidoof"}) {
+		t.Errorf("actual slots %v did not match expected slots [bidoof starly bidoof]", names)
+	}
+	engine := NewEngine(7)
+	for i := 0; i < 1000; i++ {
+		wild, ok := engine.Encounter(conditionSlots)
+		if !ok || wild.Name == "doduo" || wild.Name == "nidoran-f" || wild.Name == "kricketot" {
+			t.Fatalf("actual encounter %v did not hold at noon without a swarm or radar", wild.Name)
+		}
+	}
+	if conditions := CurrentConditions(noon.Add(11 * time.Hour)); !slices.Contains(conditions, "time-night") || !slices.Contains(conditions, "season-summer") {
+		t.Errorf("actual conditions %v missing time-night or season-summer", conditions)
+	}
+}
+
+func TestVersionSlots(t *testing.T) {
+	slots := []pokeapi.EncounterSlot{
+		{Pokemon: "psyduck", Version: "diamond"},
+		{Pokemon: "psyduck", Version: "pearl"},
+		{Pokemon: "golduck", Version: "diamond"},
+	}
+	if pearlSlots := VersionSlots(slots, "pearl"); len(pearlSlots) != 1 || pearlSlots[0].Pokemon != "psyduck" {
+		t.Errorf("actual pearl slots %v did not match expected slots [psyduck]", pearlSlots)
+	}
+	if allSlots := VersionSlots(slots, ""); len(allSlots) != len(slots) {
+		t.Errorf("no version should keep every slot, kept %v", allSlots)
+	}
+}
+
+func TestEncounterNoSlots(t *testing.T) {
+	if _, ok := NewEngine(1).Encounter(nil); ok {
+		t.Errorf("expected no encounter from an empty area")
+	}
+}
@@ -0,0 +1,102 @@
+package pokeCatch
+
+import (
+	"math"
+	"math/rand"
+)
+
+const MaxCaptureRate = 255
+
+var StatusModifiers = map[string]float64{
+	"none":      1,
+	"sleep":     2.5,
+	"freeze":    2.5,
+	"paralysis": 1.5,
+	"poison":    1.5,
+	"burn":      1.5,
+}
+
+type Attempt struct {
+	CaptureRate    int
+	BallModifier   float64
+	MaxHp          int
+	CurrentHp      int
+	StatusModifier float64
+}
+
+// FullHealth is a throw at an unhurt wild pokemon with no status, which is
+// all the REPL can offer without battles.
+func FullHealth(captureRate int, ballModifier float64) Attempt {
+	return Attempt{CaptureRate: captureRate, BallModifier: ballModifier, MaxHp: 1, CurrentHp: 1, StatusModifier: 1}
+}
+
+type Result struct {
+	Caught bool
+	Shakes int
+	Roll   float64
+	Chance float64
+}
+
+// Engine throws balls with its own random source, so a fixed seed replays
+// the same catches.
+type Engine struct {
+	rng *rand.Rand
+}
+
+func NewEngine(seed int64) *Engine {
+	return &Engine{rng: rand.New(rand.NewSource(seed))}
+}
+
+// modifiedCatchRate is the "a" value of the mainline games' capture formula.
+func modifiedCatchRate(attempt Attempt) float64 {
+	maxHp := float64(max(attempt.MaxHp, 1))
+	currentHp := float64(min(max(attempt.CurrentHp, 1), max(attempt.MaxHp, 1)))
+	ballModifier := attempt.BallModifier
+	if ballModifier <= 0 {
+		ballModifier = 1
+	}
+	statusModifier := attempt.StatusModifier
+	if statusModifier <= 0 {
+		statusModifier = 1
+	}
+	return (3*maxHp - 2*currentHp) * float64(attempt.CaptureRate) * ballModifier / (3 * maxHp) * statusModifier
+}
+
+// shakeChance is the chance each of the four shake checks passes.
+func shakeChance(attempt Attempt) float64 {
+	a := modifiedCatchRate(attempt)
+	if a >= MaxCaptureRate {
+		return 1
+	}
+	if a <= 0 {
+		return 0
+	}
+	b := 1048560 / math.Sqrt(math.Sqrt(16711680/a))
+	return math.Min(b/65536, 1)
+}
+
+func CatchChance(attempt Attempt) float64 {
+	return math.Pow(shakeChance(attempt), 4)
+}
+
+// Throw makes one roll for the whole throw. The ball shakes k times when the
+// roll is under shakeChance^k, which gives the same odds as the games' four
+// separate shake checks while leaving a single number to record.
+func (E *Engine) Throw(attempt Attempt) Result {
+	roll := E.rng.Float64()
+	chance := shakeChance(attempt)
+	result := Result{Roll: roll, Chance: math.Pow(chance, 4)}
+	shakeOdds := 1.0
+	for result.Shakes < 4 {
+		shakeOdds *= chance
+		if roll >= shakeOdds {
+			break
+		}
+		result.Shakes++
+	}
+	result.Caught = result.Shakes == 4
+	if result.Caught {
+		result.Shakes = 3
+	}
+	return result
+}
@@ -0,0 +1,82 @@
+package pokeCatch
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCatchChance(t *testing.T) {
+	cases := []struct {
+		name     string
+		attempt  Attempt
+		expected float64
+	}{
+		{name: "master ball", attempt: FullHealth(3, 255), expected: 1},
+		{name: "pidgey full hp", attempt: FullHealth(255, 1), expected: 0.3333},
+		{name: "pidgey 1hp", attempt: Attempt{CaptureRate: 255, BallModifier: 1, MaxHp: 40, CurrentHp: 1, StatusModifier: 1}, expected: 0.9833},
+		{name: "mewtwo full hp", attempt: FullHealth(3, 1), expected: 0.0039},
+		{name: "pikachu full hp", attempt: FullHealth(190, 1), expected: 0.2484},
+		{name: "pikachu great ball", attempt: FullHealth(190, 1.5), expected: 0.3725},
+		{name: "mewtwo 1hp asleep", attempt: Attempt{CaptureRate: 3, BallModifier: 1, MaxHp: 300, CurrentHp: 1, StatusModifier: StatusModifiers["sleep"]}, expected: 0.0293},
+		{name: "zero capture rate", attempt: FullHealth(0, 1), expected: 0},
+	}
+	for _, c := range cases {
+		actual := CatchChance(c.attempt)
+		if math.Abs(actual-c.expected) > 0.001 {
+			t.Errorf("%v: catch chance %.4f, expected %.4f", c.name, actual, c.expected)
+		}
+	}
+}
+
+func TestLowerHpEasier(t *testing.T) {
+	fullHp := CatchChance(Attempt{CaptureRate: 45, BallModifier: 1, MaxHp: 100, CurrentHp: 100})
+	halfHp := CatchChance(Attempt{CaptureRate: 45, BallModifier: 1, MaxHp: 100, CurrentHp: 50})
+	oneHp := CatchChance(Attempt{CaptureRate: 45, BallModifier: 1, MaxHp: 100, CurrentHp: 1})
+	if !(fullHp < halfHp && halfHp < oneHp) {
+		t.Errorf("catch chance should rise as hp falls: %v %v %v", fullHp, halfHp, oneHp)
+	}
+}
+
+func TestThrowSeeded(t *testing.T) {
+	attempt := FullHealth(45, 1)
+	first := NewEngine(42)
+	second := NewEngine(42)
+	for range 20 {
+		a, b := first.Throw(attempt), second.Throw(attempt)
+		if a != b {
+			t.Errorf("same seed gave different throws %v and %v", a, b)
+		}
+	}
+}
+
+func TestThrowMatchesChance(t *testing.T) {
+	engine := NewEngine(7)
+	attempt := FullHealth(45, 1)
+	const throws = 20000
+	caught := 0
+	for range throws {
+		result := engine.Throw(attempt)
+		if result.Caught {
+			caught++
+			if result.Roll >= result.Chance {
+				t.Errorf("caught with roll %v above chance %v", result.Roll, result.Chance)
+			}
+		}
+		if result.Shakes < 0 || result.Shakes > 3 {
+			t.Errorf("ball shook %v times", result.Shakes)
+		}
+	}
+	expected := CatchChance(attempt)
+	if actual := float64(caught) / throws; math.Abs(actual-expected) > 0.02 {
+		t.Errorf("caught %.3f of throws, expected about %.3f", actual, expected)
+	}
+}
+
+func TestThrowAlwaysCatches(t *testing.T) {
+	engine := NewEngine(1)
+	for range 100 {
+		if !engine.Throw(FullHealth(3, 255)).Caught {
+			t.Errorf("guaranteed throw missed")
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package pokeDirs
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+const appName = "pokedexcli"
+const DataDirEnv = "POKEDEX_DATA_DIR"
+const CacheDirEnv = "POKEDEX_CACHE_DIR"
+
+// DataDir is where the pokedex is saved: the flag if set, then
+// $POKEDEX_DATA_DIR, then $XDG_DATA_HOME/pokedexcli, then
+// ~/.local/share/pokedexcli.
+func DataDir(flagDir string) (string, error) {
+	return resolveDir(flagDir, DataDirEnv, "XDG_DATA_HOME", filepath.Join(".local", "share"))
+}
+
+// CacheDir is where PokeAPI responses are cached: the flag if set, then
+// $POKEDEX_CACHE_DIR, then $XDG_CACHE_HOME/pokedexcli, then
+// ~/.cache/pokedexcli.
+func CacheDir(flagDir string) (string, error) {
+	return resolveDir(flagDir, CacheDirEnv, "XDG_CACHE_HOME", ".cache")
+}
+
+func resolveDir(flagDir, appEnv, xdgEnv, homeFallback string) (string, error) {
+	if flagDir != "" {
+		return flagDir, nil
+	}
+	if dir := os.Getenv(appEnv); dir != "" {
+		return dir, nil
+	}
+	// the XDG spec says relative paths are invalid and should be ignored
+	if xdgDir := os.Getenv(xdgEnv); filepath.IsAbs(xdgDir) {
+		return filepath.Join(xdgDir, appName), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", errors.New("no home directory, set " + appEnv)
+	}
+	return filepath.Join(home, homeFallback, appName), nil
+}
@@ -0,0 +1,55 @@
+package pokeDirs
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDataDir(t *testing.T) {
+	t.Setenv("HOME", "/home/ash")
+	t.Setenv(DataDirEnv, "")
+	t.Setenv("XDG_DATA_HOME", "")
+	cases := []struct {
+		flagDir  string
+		envDir   string
+		xdgDir   string
+		expected string
+	}{
+		{expected: filepath.Join("/home/ash", ".local", "share", appName)},
+		{xdgDir: "/xdg/data", expected: filepath.Join("/xdg/data", appName)},
+		{xdgDir: "relative/data", expected: filepath.Join("/home/ash", ".local", "share", appName)},
+		{envDir: "/env/data", xdgDir: "/xdg/data", expected: "/env/data"},
+		{flagDir: "/flag/data", envDir: "/env/data", xdgDir: "/xdg/data", expected: "/flag/data"},
+	}
+	for _, c := range cases {
+		t.Setenv(DataDirEnv, c.envDir)
+		t.Setenv("XDG_DATA_HOME", c.xdgDir)
+		actual, err := DataDir(c.flagDir)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if actual != c.expected {
+			t.Errorf("actual data dir '%v' did not match expected '%v'", actual, c.expected)
+		}
+	}
+}
+
+func TestCacheDir(t *testing.T) {
+	t.Setenv("HOME", "/home/ash")
+	t.Setenv(CacheDirEnv, "")
+	t.Setenv("XDG_CACHE_HOME", "")
+	actual, err := CacheDir("")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if expected := filepath.Join("/home/ash", ".cache", appName); actual != expected {
+		t.Errorf("actual cache dir '%v' did not match expected '%v'", actual, expected)
+	}
+	t.Setenv("XDG_CACHE_HOME", "/xdg/cache")
+	actual, _ = CacheDir("")
+	if expected := filepath.Join("/xdg/cache", appName); actual != expected {
+		t.Errorf("actual cache dir '%v' did not match expected '%v'", actual, expected)
+	}
+}
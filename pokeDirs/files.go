@@ -0,0 +1,29 @@
+package pokeDirs
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// WriteFileAtomic writes to a temporary file next to filePath, syncs it and
+// renames it into place, so readers see either the old or the new contents
+// and never a partial write.
+func WriteFileAtomic(filePath string, data []byte) error {
+	tempFile, err := os.CreateTemp(filepath.Dir(filePath), filepath.Base(filePath)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tempFile.Name())
+	if _, err := tempFile.Write(data); err != nil {
+		tempFile.Close()
+		return err
+	}
+	if err := tempFile.Sync(); err != nil {
+		tempFile.Close()
+		return err
+	}
+	if err := tempFile.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tempFile.Name(), filePath)
+}
@@ -0,0 +1,204 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/asrioth/pokedexcli/pokeCache"
+)
+
+const DefaultTTL = time.Hour
+const DefaultMemoryEntries = 500
+
+// CachedResources are the resource types the client caches, each of which
+// can also have its full list of names cached as <resource>-list.
+var CachedResources = []string{"region", "location", areaResource, areaPageResource, "pokemon", "pokemon-species", "version", "type"}
+
+// IsCachedResource checks a resource type, or its list, is one the client
+// caches, so TTLs aren't set for misspelt ones.
+func IsCachedResource(resource string) bool {
+	return slices.Contains(CachedResources, strings.TrimSuffix(resource, listResourceSuffix))
+}
+
+// Cache is one layer PokeAPI resources are kept in, by resource type and id
+// with names looked up to ids. Store is the disk layer, MemoryCache the
+// memory one, and ChainedCache stacks them in front of the network. Has only
+// checks an entry is there, without reading it.
+//
+// Load is how the client reads through the layers. It calls fetch, the next
+// layer down, when the entry is missing, and once the entry is older than its
+// resource type's TTL returns it as is while fetch refreshes it in the
+// background.
+type Cache interface {
+	Get(resource string, id int) ([]byte, bool)
+	Has(resource string, id int) bool
+	Lookup(resource, name string) (int, bool)
+	Load(ctx context.Context, resource string, id int, fetch func(context.Context) ([]byte, error)) ([]byte, error)
+	Put(resource string, id int, name string, data []byte) error
+}
+
+func getByName(cache Cache, resource, name string) ([]byte, bool) {
+	id, ok := cache.Lookup(resource, name)
+	if !ok {
+		return nil, false
+	}
+	return cache.Get(resource, id)
+}
+
+func resourceTTL(ttls map[string]time.Duration, resource string) time.Duration {
+	if ttl, ok := ttls[resource]; ok {
+		return ttl
+	}
+	return DefaultTTL
+}
+
+// MemoryCache keeps the most recently used resources in memory for their
+// resource type's TTL, or DefaultTTL when it has none, after which Load
+// serves them stale while reloading them. A TTL of 0 keeps that resource type
+// out of memory.
+type MemoryCache struct {
+	entries *pokeCache.Cache[string, []byte]
+	names   *pokeCache.Cache[string, int]
+	ttls    map[string]time.Duration
+}
+
+func NewMemoryCache(ttls map[string]time.Duration, maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		entries: pokeCache.NewCache[string, []byte](0, maxEntries),
+		names:   pokeCache.NewCache[string, int](0, maxEntries),
+		ttls:    ttls,
+	}
+}
+
+func (M *MemoryCache) ttl(resource string) time.Duration {
+	return resourceTTL(M.ttls, resource)
+}
+
+func memoryKey(resource, key string) string {
+	return resource + "/" + key
+}
+
+func (M *MemoryCache) Get(resource string, id int) ([]byte, bool) {
+	if M == nil {
+		return nil, false
+	}
+	return M.entries.Get(memoryKey(resource, strconv.Itoa(id)))
+}
+
+func (M *MemoryCache) Has(resource string, id int) bool {
+	if M == nil {
+		return false
+	}
+	return M.entries.Has(memoryKey(resource, strconv.Itoa(id)))
+}
+
+func (M *MemoryCache) Lookup(resource, name string) (int, bool) {
+	if M == nil {
+		return 0, false
+	}
+	return M.names.Get(memoryKey(resource, name))
+}
+
+func (M *MemoryCache) Load(ctx context.Context, resource string, id int, fetch func(context.Context) ([]byte, error)) ([]byte, error) {
+	if M == nil || M.ttl(resource) <= 0 {
+		return fetch(ctx)
+	}
+	return M.entries.GetOrLoad(ctx, memoryKey(resource, strconv.Itoa(id)), M.ttl(resource), fetch)
+}
+
+func (M *MemoryCache) Put(resource string, id int, name string, data []byte) error {
+	if M == nil {
+		return nil
+	}
+	ttl := M.ttl(resource)
+	if ttl <= 0 {
+		return nil
+	}
+	M.entries.Add(memoryKey(resource, strconv.Itoa(id)), data, ttl)
+	if name != "" {
+		M.names.Add(memoryKey(resource, name), id, ttl)
+	}
+	return nil
+}
+
+func (M *MemoryCache) Stats() pokeCache.Stats {
+	if M == nil {
+		return pokeCache.Stats{}
+	}
+	return M.entries.Stats()
+}
+
+// ChainedCache checks its layers in order and puts into every layer. Get
+// copies whatever a later layer has into the earlier ones, Load leaves that
+// to each layer so none hides how old a later layer's entry is.
+type ChainedCache struct {
+	Layers []Cache
+}
+
+func NewChainedCache(layers ...Cache) *ChainedCache {
+	return &ChainedCache{Layers: layers}
+}
+
+func (C *ChainedCache) Get(resource string, id int) ([]byte, bool) {
+	for index, layer := range C.Layers {
+		if data, ok := layer.Get(resource, id); ok {
+			C.fill(index, resource, id, data)
+			return data, true
+		}
+	}
+	return nil, false
+}
+
+// Has doesn't fill, so checking many entries leaves the earlier layers as
+// they were.
+func (C *ChainedCache) Has(resource string, id int) bool {
+	for _, layer := range C.Layers {
+		if layer.Has(resource, id) {
+			return true
+		}
+	}
+	return false
+}
+
+func (C *ChainedCache) Lookup(resource, name string) (int, bool) {
+	for _, layer := range C.Layers {
+		if id, ok := layer.Lookup(resource, name); ok {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+func (C *ChainedCache) Load(ctx context.Context, resource string, id int, fetch func(context.Context) ([]byte, error)) ([]byte, error) {
+	return C.load(ctx, 0, resource, id, fetch)
+}
+
+// load has each layer fall back on the ones after it, and the last on fetch.
+func (C *ChainedCache) load(ctx context.Context, layer int, resource string, id int, fetch func(context.Context) ([]byte, error)) ([]byte, error) {
+	if layer == len(C.Layers) {
+		return fetch(ctx)
+	}
+	return C.Layers[layer].Load(ctx, resource, id, func(ctx context.Context) ([]byte, error) {
+		return C.load(ctx, layer+1, resource, id, fetch)
+	})
+}
+
+// fill copies a hit in layer found into the layers before it. Failing to
+// copy only costs a slower lookup next time, so errors are dropped.
+func (C *ChainedCache) fill(found int, resource string, id int, data []byte) {
+	for _, layer := range C.Layers[:found] {
+		layer.Put(resource, id, "", data)
+	}
+}
+
+func (C *ChainedCache) Put(resource string, id int, name string, data []byte) error {
+	var errs []error
+	for _, layer := range C.Layers {
+		errs = append(errs, layer.Put(resource, id, name, data))
+	}
+	return errors.Join(errs...)
+}
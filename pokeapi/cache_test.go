@@ -0,0 +1,184 @@
+package pokeapi
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMemoryCacheTTL(t *testing.T) {
+	cache := NewMemoryCache(map[string]time.Duration{"pokemon": 0, "location-area": time.Millisecond}, 10)
+	cache.Put("pokemon", 25, "pikachu", []byte(`{"id":25}`))
+	if _, ok := cache.Get("pokemon", 25); ok {
+		t.Errorf("expected a 0 ttl resource to be kept out of memory")
+	}
+	cache.Put("location-area", 2, "eterna-city-area", []byte(`{"id":2}`))
+	cache.Put("pokemon-species", 25, "pikachu", []byte(`{"id":25}`))
+	time.Sleep(5 * time.Millisecond)
+	if _, ok := cache.Get("location-area", 2); ok {
+		t.Errorf("expected an expired resource to miss")
+	}
+	if id, ok := cache.Lookup("pokemon-species", "pikachu"); !ok || id != 25 {
+		t.Errorf("actual id %v did not match expected id 25 for a resource with the default ttl", id)
+	}
+}
+
+func TestChainedCacheFill(t *testing.T) {
+	memory := NewMemoryCache(nil, 10)
+	store, err := OpenStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.Put("pokemon", 25, "pikachu", []byte(`{"id":25}`))
+	cache := NewChainedCache(memory, store)
+	data, ok := getByName(cache, "pokemon", "pikachu")
+	if !ok || string(data) != `{"id":25}` {
+		t.Errorf("actual data '%s' did not match expected data from the disk layer", data)
+	}
+	if _, ok := memory.Get("pokemon", 25); !ok {
+		t.Errorf("expected a disk hit to be copied into memory")
+	}
+	if err := cache.Put("pokemon", 7, "squirtle", []byte(`{"id":7}`)); err != nil {
+		t.Error(err)
+	}
+	if !store.Has("pokemon", 7) {
+		t.Errorf("expected put to reach the disk layer")
+	}
+	if _, ok := memory.Get("pokemon", 7); !ok {
+		t.Errorf("expected put to reach the memory layer")
+	}
+}
+
+func TestChainedCacheHasNoFill(t *testing.T) {
+	memory := NewMemoryCache(nil, 10)
+	store, err := OpenStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.Put("pokemon", 25, "pikachu", []byte(`{"id":25}`))
+	cache := NewChainedCache(memory, store)
+	if !cache.Has("pokemon", 25) || cache.Has("pokemon", 7) {
+		t.Errorf("actual Has results did not match the stored entries")
+	}
+	if memory.Has("pokemon", 25) {
+		t.Errorf("expected Has not to copy the disk entry into memory")
+	}
+}
+
+// staleClient's pokemon go stale after ttl, both in memory and on disk.
+func staleClient(t *testing.T, ttl time.Duration) (*Client, *atomic.Int32) {
+	var requests atomic.Int32
+	client := newFakeClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.Trim(r.URL.Path, "/") == "pokemon/pikachu" {
+			requests.Add(1)
+		}
+		fakeApiHandler(w, r)
+	})
+	ttls := map[string]time.Duration{"pokemon": ttl}
+	store := fakeStore(client)
+	store.TTLs = ttls
+	client.Cache = NewChainedCache(NewMemoryCache(ttls, DefaultMemoryEntries), store)
+	return client, &requests
+}
+
+// waitForRequests gives background refreshes a moment to reach the server.
+func waitForRequests(requests *atomic.Int32, expected int32) int32 {
+	deadline := time.Now().Add(time.Second)
+	for requests.Load() < expected && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	return requests.Load()
+}
+
+func TestStaleRefreshedOnce(t *testing.T) {
+	const ttl = 100 * time.Millisecond
+	client, requests := staleClient(t, ttl)
+	if _, err := client.GetPokemonStats(context.Background(), "pikachu"); err != nil {
+		t.Error(err)
+		return
+	}
+	time.Sleep(ttl)
+	for range 5 {
+		pokemonDescription, err := client.GetPokemonStats(context.Background(), "pikachu")
+		if err != nil || pokemonDescription.Hp != 35 {
+			t.Errorf("expected the stale pikachu while refreshing, got %v (%v)", pokemonDescription, err)
+			return
+		}
+	}
+	if actual := waitForRequests(requests, 2); actual != 2 {
+		t.Errorf("actual requests %v did not match expected requests 2, a stale resource should be refetched once", actual)
+	}
+	if stats := client.Cache.(*ChainedCache).Layers[0].(*MemoryCache).Stats(); stats.Stale == 0 {
+		t.Errorf("expected the memory layer to serve the stale entry")
+	}
+}
+
+func TestStaleOnDiskRefreshedOnce(t *testing.T) {
+	const ttl = 100 * time.Millisecond
+	client, requests := staleClient(t, ttl)
+	if _, err := client.GetPokemonStats(context.Background(), "pikachu"); err != nil {
+		t.Error(err)
+		return
+	}
+	time.Sleep(ttl)
+	// a new memory layer, as after a restart, leaves only the stale file
+	cache := client.Cache.(*ChainedCache)
+	cache.Layers[0] = NewMemoryCache(map[string]time.Duration{"pokemon": ttl}, DefaultMemoryEntries)
+	for range 5 {
+		if _, err := client.GetPokemonStats(context.Background(), "pikachu"); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	if actual := waitForRequests(requests, 2); actual != 2 {
+		t.Errorf("actual requests %v did not match expected requests 2, a stale file should be refetched once", actual)
+	}
+	info, err := os.Stat(fakeStore(client).dataPath("pokemon", 25))
+	if err != nil || time.Since(info.ModTime()) >= ttl {
+		t.Errorf("expected the refetch to rewrite the stale file")
+	}
+}
+
+func TestOfflineServesStale(t *testing.T) {
+	client, requests := staleClient(t, time.Nanosecond)
+	if _, err := client.GetPokemonStats(context.Background(), "pikachu"); err != nil {
+		t.Error(err)
+		return
+	}
+	client.Offline = true
+	if _, err := client.GetPokemonStats(context.Background(), "pikachu"); err != nil {
+		t.Errorf("expected the stale pikachu offline, got %v", err)
+	}
+	if actual := waitForRequests(requests, 1); actual != 1 {
+		t.Errorf("actual requests %v did not match expected requests 1 while offline", actual)
+	}
+}
+
+func TestResourceListCached(t *testing.T) {
+	var listRequests atomic.Int32
+	client := newFakeClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/region" {
+			listRequests.Add(1)
+		}
+		fakeApiHandler(w, r)
+	})
+	for range 2 {
+		if _, err := client.GetRegions(context.Background()); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	client.Cache.(*ChainedCache).Layers[0] = NewMemoryCache(nil, DefaultMemoryEntries)
+	if _, err := client.GetRegions(context.Background()); err != nil {
+		t.Error(err)
+		return
+	}
+	if requests := listRequests.Load(); requests != 1 {
+		t.Errorf("actual list requests %v did not match expected requests 1", requests)
+	}
+}
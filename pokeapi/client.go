@@ -0,0 +1,145 @@
+package pokeapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const DefaultBaseUrl = "https://pokeapi.co/api/v2"
+const DefaultUserAgent = "pokedexcli"
+const DefaultTimeout = 10 * time.Second
+const DefaultWorkers = 4
+const DefaultRequestsPerSecond = 20
+
+const regionEndpoint = "/region/%v/"
+const locationEndpoint = "/location/%v/"
+const areaEndpoint = "/location-area/%v/"
+const areaListEndpoint = "/location-area?offset=%v&limit=%v"
+const areaPageLimit = 20
+const pokemonEndpoint = "/pokemon/%v/"
+const speciesEndpoint = "/pokemon-species/%v/"
+const versionEndpoint = "/version/%v/"
+const typeEndpoint = "/type/%v/"
+
+type Client struct {
+	BaseUrl    string
+	HttpClient *http.Client
+	UserAgent  string
+	Timeout    time.Duration
+	Retry      RetryPolicy
+	Workers    int
+	Limiter    *RateLimiter
+	Cache      Cache
+	// Offline serves strictly from Cache, every miss is an OfflineError
+	Offline bool
+	flights flightGroup
+}
+
+func NewClient(baseUrl string, timeout time.Duration) *Client {
+	if baseUrl == "" {
+		baseUrl = DefaultBaseUrl
+	}
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Client{
+		BaseUrl:    strings.TrimSuffix(baseUrl, "/"),
+		HttpClient: &http.Client{Timeout: timeout},
+		UserAgent:  DefaultUserAgent,
+		Timeout:    timeout,
+		Retry:      DefaultRetryPolicy,
+		Workers:    DefaultWorkers,
+		Limiter:    NewRateLimiter(DefaultRequestsPerSecond),
+		Cache:      NewMemoryCache(nil, DefaultMemoryEntries),
+	}
+}
+
+// cache is the client's Cache, or a nil Store caching nothing when it has
+// none.
+func (C *Client) cache() Cache {
+	if C.Cache == nil {
+		return (*Store)(nil)
+	}
+	return C.Cache
+}
+
+func (C *Client) endpointUrl(endpoint string) string {
+	return C.BaseUrl + endpoint
+}
+
+func (C *Client) get(ctx context.Context, url string) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := C.Limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	if C.UserAgent != "" {
+		request.Header.Set("User-Agent", C.UserAgent)
+	}
+	httpClient := C.HttpClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: C.Timeout}
+	}
+	return httpClient.Do(request)
+}
+
+func fetchJson[T any](ctx context.Context, client *Client, endpoint, key string) (T, error) {
+	currentUrl := fmt.Sprintf(client.endpointUrl(endpoint), key)
+	return fetchUrlJson[T](ctx, client, currentUrl, endpointResource(endpoint), key)
+}
+
+func fetchUrlJson[T any](ctx context.Context, client *Client, currentUrl, resource, key string) (T, error) {
+	var value T
+	body, err := client.fetch(ctx, currentUrl, resource, key)
+	if err != nil {
+		return value, err
+	}
+	if err := json.Unmarshal(body, &value); err != nil {
+		var empty T
+		return empty, &DecodeError{Url: currentUrl, Err: err}
+	}
+	return value, nil
+}
+
+func (C *Client) fetch(ctx context.Context, currentUrl, resource, key string) ([]byte, error) {
+	if C.Offline {
+		return nil, &OfflineError{Url: currentUrl, Resource: resource, Name: key}
+	}
+	var err error
+	for attempt := 1; ; attempt++ {
+		var body []byte
+		body, err = C.fetchOnce(ctx, currentUrl, resource, key)
+		if err == nil {
+			return body, nil
+		}
+		if attempt >= C.Retry.MaxAttempts || !retryable(ctx, err) {
+			return nil, err
+		}
+		delay, ok := C.Retry.delay(attempt, err)
+		if !ok {
+			return nil, err
+		}
+		if waitErr := sleepContext(ctx, delay); waitErr != nil {
+			return nil, waitErr
+		}
+	}
+}
+
+func (C *Client) fetchOnce(ctx context.Context, currentUrl, resource, key string) ([]byte, error) {
+	getResult, err := C.get(ctx, currentUrl)
+	if err != nil {
+		return nil, err
+	}
+	defer getResult.Body.Close()
+	if err := checkStatus(getResult, resource, key); err != nil {
+		return nil, err
+	}
+	return io.ReadAll(getResult.Body)
+}
@@ -0,0 +1,54 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClientUserAgent(t *testing.T) {
+	var userAgent string
+	client := newFakeClient(t, func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		fakeApiHandler(w, r)
+	})
+	client.UserAgent = "pokedexcli-test"
+	if _, err := client.GetPokemonStats(context.Background(), "squirtle"); err != nil {
+		t.Error(err)
+		return
+	}
+	if userAgent != "pokedexcli-test" {
+		t.Errorf("actual user agent '%v' did not match expected user agent 'pokedexcli-test'", userAgent)
+	}
+}
+
+func TestNewClientDefaults(t *testing.T) {
+	client := NewClient("", 0)
+	if client.BaseUrl != DefaultBaseUrl {
+		t.Errorf("actual base url '%v' did not match default '%v'", client.BaseUrl, DefaultBaseUrl)
+	}
+	if client.Timeout != DefaultTimeout {
+		t.Errorf("actual timeout %v did not match default %v", client.Timeout, DefaultTimeout)
+	}
+	client = NewClient("http://localhost:8080/api/v2/", 0)
+	if client.BaseUrl != "http://localhost:8080/api/v2" {
+		t.Errorf("trailing slash not trimmed from base url '%v'", client.BaseUrl)
+	}
+}
+
+func TestClientCancel(t *testing.T) {
+	client := newFakeClient(t, func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	_, err := client.GetPokemonStats(ctx, "squirtle")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
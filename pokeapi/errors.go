@@ -0,0 +1,103 @@
+package pokeapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrNotFound = errors.New("not found")
+var ErrRateLimited = errors.New("rate limited")
+var ErrServer = errors.New("server error")
+var ErrDecode = errors.New("decode failure")
+var ErrOffline = errors.New("not cached while offline")
+
+type StatusError struct {
+	Url        string
+	StatusCode int
+	Resource   string
+	Name       string
+	RetryAfter time.Duration
+}
+
+func (E *StatusError) Error() string {
+	return fmt.Sprintf("get %v: %v %v", E.Url, E.StatusCode, http.StatusText(E.StatusCode))
+}
+
+func (E *StatusError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return E.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return E.StatusCode == http.StatusTooManyRequests
+	case ErrServer:
+		return E.StatusCode >= 500
+	}
+	return false
+}
+
+type DecodeError struct {
+	Url string
+	Err error
+}
+
+func (E *DecodeError) Error() string {
+	return fmt.Sprintf("decode %v: %v", E.Url, E.Err)
+}
+
+func (E *DecodeError) Unwrap() error {
+	return E.Err
+}
+
+func (E *DecodeError) Is(target error) bool {
+	return target == ErrDecode
+}
+
+type OfflineError struct {
+	Url      string
+	Resource string
+	Name     string
+}
+
+func (E *OfflineError) Error() string {
+	return fmt.Sprintf("get %v: not cached while offline", E.Url)
+}
+
+func (E *OfflineError) Is(target error) bool {
+	return target == ErrOffline
+}
+
+func checkStatus(response *http.Response, resource, key string) error {
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		return nil
+	}
+	return &StatusError{
+		Url:        response.Request.URL.String(),
+		StatusCode: response.StatusCode,
+		Resource:   resource,
+		Name:       key,
+		RetryAfter: parseRetryAfter(response.Header.Get("Retry-After")),
+	}
+}
+
+func parseRetryAfter(retryAfter string) time.Duration {
+	if retryAfter == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(retryAfter); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if retryTime, err := http.ParseTime(retryAfter); err == nil {
+		return max(time.Until(retryTime), 0)
+	}
+	return 0
+}
+
+func endpointResource(endpoint string) string {
+	resource, _, _ := strings.Cut(strings.Trim(endpoint, "/"), "/")
+	resource, _, _ = strings.Cut(resource, "?")
+	return resource
+}
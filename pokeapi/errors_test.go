@@ -0,0 +1,99 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestNotFoundError(t *testing.T) {
+	client := newFakeClient(t, fakeApiHandler)
+	_, err := client.GetPokemonStats(context.Background(), "pikachuu")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+		return
+	}
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		t.Errorf("expected StatusError, got %T", err)
+		return
+	}
+	if statusErr.Resource != "pokemon" || statusErr.Name != "pikachuu" {
+		t.Errorf("wrong resource '%v' or name '%v' in error", statusErr.Resource, statusErr.Name)
+	}
+}
+
+func TestStatusErrors(t *testing.T) {
+	cases := []struct {
+		status     int
+		retryAfter string
+		expected   error
+	}{
+		{status: http.StatusTooManyRequests, retryAfter: "7", expected: ErrRateLimited},
+		{status: http.StatusInternalServerError, expected: ErrServer},
+		{status: http.StatusBadGateway, expected: ErrServer},
+	}
+	for _, c := range cases {
+		client := newFakeClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if c.retryAfter != "" {
+				w.Header().Set("Retry-After", c.retryAfter)
+			}
+			w.WriteHeader(c.status)
+		})
+		client.Retry = RetryPolicy{MaxAttempts: 1}
+		_, err := client.GetPokemonStats(context.Background(), "squirtle")
+		if !errors.Is(err, c.expected) {
+			t.Errorf("status %v: expected %v, got %v", c.status, c.expected, err)
+			continue
+		}
+		if errors.Is(err, ErrNotFound) {
+			t.Errorf("status %v: unexpectedly matched ErrNotFound", c.status)
+		}
+		var statusErr *StatusError
+		if c.retryAfter != "" && errors.As(err, &statusErr) && statusErr.RetryAfter != 7*time.Second {
+			t.Errorf("wrong retry after %v", statusErr.RetryAfter)
+		}
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+	client := newFakeClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html>not json</html>"))
+	})
+	_, err := client.GetPokemonStats(context.Background(), "squirtle")
+	if !errors.Is(err, ErrDecode) {
+		t.Errorf("expected ErrDecode, got %v", err)
+	}
+}
+
+func TestSuggestNames(t *testing.T) {
+	client := newFakeClient(t, fakeApiHandler)
+	suggestions, err := client.SuggestNames(context.Background(), "pokemon", "pikachuu", 3)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(suggestions) != 1 || suggestions[0] != "pikachu" {
+		t.Errorf("actual suggestions %v did not match expected [pikachu]", suggestions)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	cases := []struct {
+		a, b     string
+		expected int
+	}{
+		{"pikachu", "pikachu", 0},
+		{"pikachuu", "pikachu", 1},
+		{"charmandr", "charmander", 1},
+		{"", "abc", 3},
+		{"squirtle", "sqiurtle", 2},
+	}
+	for _, c := range cases {
+		if actual := editDistance(c.a, c.b); actual != c.expected {
+			t.Errorf("editDistance(%v, %v) = %v, expected %v", c.a, c.b, actual, c.expected)
+		}
+	}
+}
@@ -0,0 +1,332 @@
+package pokeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var fakeAreaNames = []string{"canalave-city-area",
+	"eterna-city-area",
+	"pastoria-city-area",
+	"sunyshore-city-area",
+	"sinnoh-pokemon-league-area",
+	"oreburgh-mine-1f",
+	"oreburgh-mine-b1f",
+	"valley-windworks-area",
+	"eterna-forest-area",
+	"fuego-ironworks-area",
+	"mt-coronet-1f-route-207",
+	"mt-coronet-2f",
+	"mt-coronet-3f",
+	"mt-coronet-exterior-snowfall",
+	"mt-coronet-exterior-blizzard",
+	"mt-coronet-4f",
+	"mt-coronet-4f-small-room",
+	"mt-coronet-5f",
+	"mt-coronet-6f",
+	"mt-coronet-1f-from-exterior",
+	"mt-coronet-b1f",
+	"great-marsh-area-1",
+	"great-marsh-area-2",
+	"solaceon-ruins-2f"}
+
+var fakeAreaPokemon = map[string][]string{
+	"eterna-city-area": {"psyduck", "golduck", "magikarp", "gyarados", "barboach", "whiscash"},
+}
+
+// fakeEncounters are the eterna-city-area encounters in diamond, as
+// pokemon: method, chance, min level, max level.
+var fakeEncounters = map[string][]any{
+	"psyduck":  {"surf", 90, 20, 30},
+	"golduck":  {"surf", 10, 20, 40},
+	"magikarp": {"old-rod", 100, 3, 15},
+	"gyarados": {"good-rod", 40, 15, 25},
+	"barboach": {"good-rod", 60, 10, 25},
+	"whiscash": {"super-rod", 100, 30, 55},
+}
+
+// fakeEncounterVersions are the versions other than diamond pokemon can be
+// found in.
+var fakeEncounterVersions = map[string][]string{
+	"psyduck":  {"pearl"},
+	"magikarp": {"pearl", "platinum"},
+}
+
+// fakeEncounterConditions are the condition values on a pokemon's
+// encounters.
+var fakeEncounterConditions = map[string][]string{
+	"gyarados": {"time-night"},
+}
+
+var fakeTypes = []string{"normal", "fire", "water", "electric"}
+
+var fakeVersions = []string{"red", "blue", "diamond", "pearl", "platinum"}
+
+var fakeRegions = map[string][]string{
+	"kanto":  {"pallet-town", "viridian-forest"},
+	"sinnoh": {"eterna-city", "mt-coronet", "great-marsh"},
+}
+
+var fakeRegionNames = []string{"kanto", "sinnoh"}
+
+var fakeLocations = map[string][]string{
+	"pallet-town": {},
+	"eterna-city": {"eterna-city-area"},
+	"mt-coronet":  {"mt-coronet-1f-route-207", "mt-coronet-2f", "mt-coronet-3f"},
+	"great-marsh": {"great-marsh-area-1", "great-marsh-area-2"},
+}
+
+var fakeLocationNames = []string{"pallet-town", "viridian-forest", "eterna-city", "mt-coronet", "great-marsh"}
+
+func fakeNamedResourcesJson(names []string) []map[string]any {
+	resources := []map[string]any{}
+	for _, name := range names {
+		resources = append(resources, map[string]any{"name": name})
+	}
+	return resources
+}
+
+var fakeCaptureRates = map[string]int{"squirtle": 45, "pikachu": 190}
+
+var fakePokemon = map[string]map[string]any{
+	"squirtle": fakePokemonJson(7, "squirtle", 63, 5, 90, []int{44, 48, 65, 50, 64, 43}, "water"),
+	"pikachu":  fakePokemonJson(25, "pikachu", 112, 4, 60, []int{35, 55, 40, 50, 50, 90}, "electric"),
+}
+
+func fakePokemonJson(id int, name string, baseXp, height, weight int, stats []int, types ...string) map[string]any {
+	statNames := []string{"hp", "attack", "defense", "special-attack", "special-defense", "speed"}
+	var statsJson []map[string]any
+	for index, stat := range stats {
+		statsJson = append(statsJson, map[string]any{"base_stat": stat, "stat": map[string]any{"name": statNames[index]}})
+	}
+	var typesJson []map[string]any
+	for index, pokeType := range types {
+		typesJson = append(typesJson, map[string]any{"slot": index + 1, "type": map[string]any{"name": pokeType}})
+	}
+	return map[string]any{
+		"id":              id,
+		"name":            name,
+		"base_experience": baseXp,
+		"height":          height,
+		"weight":          weight,
+		"stats":           statsJson,
+		"types":           typesJson,
+		"species":         map[string]any{"name": name},
+	}
+}
+
+// fakeAreaIdAt leaves gaps in the ids after the first page, like the real
+// PokeAPI does.
+func fakeAreaIdAt(index int) int {
+	if index < 20 {
+		return index + 1
+	}
+	return 2*index - 18
+}
+
+func fakeConditionsJson(conditions []string) []map[string]any {
+	conditionsJson := []map[string]any{}
+	for _, condition := range conditions {
+		conditionsJson = append(conditionsJson, map[string]any{"name": condition})
+	}
+	return conditionsJson
+}
+
+func fakeAreaJson(index int) map[string]any {
+	name := fakeAreaNames[index]
+	var encounters []map[string]any
+	for _, pokemon := range fakeAreaPokemon[name] {
+		encounter := map[string]any{"pokemon": map[string]any{"name": pokemon}}
+		if details, ok := fakeEncounters[pokemon]; ok {
+			var versionDetails []map[string]any
+			for _, version := range append([]string{"diamond"}, fakeEncounterVersions[pokemon]...) {
+				versionDetails = append(versionDetails, map[string]any{
+					"version": map[string]any{"name": version},
+					"encounter_details": []map[string]any{{
+						"method":           map[string]any{"name": details[0]},
+						"chance":           details[1],
+						"min_level":        details[2],
+						"max_level":        details[3],
+						"condition_values": fakeConditionsJson(fakeEncounterConditions[pokemon]),
+					}},
+				})
+			}
+			encounter["version_details"] = versionDetails
+		}
+		encounters = append(encounters, encounter)
+	}
+	return map[string]any{"id": fakeAreaIdAt(index), "name": name, "pokemon_encounters": encounters}
+}
+
+func fakeAreaIndex(key string) (int, bool) {
+	for index, name := range fakeAreaNames {
+		if name == key || strconv.Itoa(fakeAreaIdAt(index)) == key {
+			return index, true
+		}
+	}
+	return 0, false
+}
+
+func fakePokemonByKey(key string) (map[string]any, bool) {
+	for name, pokemon := range fakePokemon {
+		if name == key || strconv.Itoa(pokemon["id"].(int)) == key {
+			return pokemon, true
+		}
+	}
+	return nil, false
+}
+
+func fakeApiHandler(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) == 1 {
+		fakeListHandler(w, r, parts[0])
+		return
+	}
+	if len(parts) != 2 {
+		http.NotFound(w, r)
+		return
+	}
+	var body any
+	switch parts[0] {
+	case "location-area":
+		index, ok := fakeAreaIndex(parts[1])
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		body = fakeAreaJson(index)
+	case "pokemon":
+		pokemon, ok := fakePokemonByKey(parts[1])
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		body = pokemon
+	case "pokemon-species":
+		pokemon, ok := fakePokemonByKey(parts[1])
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		name := pokemon["name"].(string)
+		body = map[string]any{"id": pokemon["id"], "name": name, "capture_rate": fakeCaptureRates[name]}
+	case "region":
+		locations, ok := fakeRegions[parts[1]]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		body = map[string]any{"id": slices.Index(fakeRegionNames, parts[1]) + 1, "name": parts[1], "locations": fakeNamedResourcesJson(locations)}
+	case "location":
+		areas, ok := fakeLocations[parts[1]]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		body = map[string]any{"id": slices.Index(fakeLocationNames, parts[1]) + 1, "name": parts[1], "areas": fakeNamedResourcesJson(areas)}
+	case "type":
+		index := slices.IndexFunc(fakeTypes, func(name string) bool {
+			return name == parts[1] || strconv.Itoa(slices.Index(fakeTypes, name)+1) == parts[1]
+		})
+		if index < 0 {
+			http.NotFound(w, r)
+			return
+		}
+		body = map[string]any{"id": index + 1, "name": fakeTypes[index]}
+	case "version":
+		index := slices.Index(fakeVersions, parts[1])
+		if index < 0 {
+			http.NotFound(w, r)
+			return
+		}
+		body = map[string]any{"id": index + 1, "name": parts[1]}
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(body)
+}
+
+func fakeListHandler(w http.ResponseWriter, r *http.Request, resource string) {
+	var names []string
+	var ids []int
+	switch resource {
+	case "location-area":
+		names = fakeAreaNames
+		for index := range names {
+			ids = append(ids, fakeAreaIdAt(index))
+		}
+	case "region":
+		names = fakeRegionNames
+		for index := range names {
+			ids = append(ids, index+1)
+		}
+	case "type":
+		names = fakeTypes
+		for index := range names {
+			ids = append(ids, index+1)
+		}
+	case "pokemon", "pokemon-species":
+		for name := range fakePokemon {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			ids = append(ids, fakePokemon[name]["id"].(int))
+		}
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil {
+		limit = 20
+	}
+	pageUrl := func(pageOffset int) *string {
+		url := fmt.Sprintf("http://%v/%v/?offset=%v&limit=%v", r.Host, resource, pageOffset, limit)
+		return &url
+	}
+	resourceList := NamedResourceList{Count: len(names)}
+	for index := offset; index < len(names) && index < offset+limit; index++ {
+		url := fmt.Sprintf("http://%v/%v/%v/", r.Host, resource, ids[index])
+		resourceList.Results = append(resourceList.Results, NamedResource{Name: names[index], URL: url})
+	}
+	if offset+limit < len(names) {
+		resourceList.Next = pageUrl(offset + limit)
+	}
+	if offset > 0 {
+		resourceList.Previous = pageUrl(max(offset-limit, 0))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resourceList)
+}
+
+// newFakeClient returns a client talking to an in-process stand-in for the
+// PokeAPI, caching into a scratch directory so tests don't share data.
+func newFakeClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	store, err := OpenStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := NewClient(server.URL, 0)
+	client.Limiter = nil
+	client.Cache = NewChainedCache(NewMemoryCache(nil, DefaultMemoryEntries), store)
+	return client
+}
+
+// fakeStore is the disk layer of a client made by newFakeClient.
+func fakeStore(client *Client) *Store {
+	return client.Cache.(*ChainedCache).Layers[1].(*Store)
+}
@@ -0,0 +1,77 @@
+package pokeapi
+
+import (
+	"context"
+	"sync"
+)
+
+type flight struct {
+	done    chan struct{}
+	value   any
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// flightGroup shares one call between everyone asking for the same key at
+// the same time. The call only gets cancelled once every caller waiting on
+// it has given up.
+type flightGroup struct {
+	flights map[string]*flight
+	lock    sync.Mutex
+}
+
+func (G *flightGroup) do(ctx context.Context, key string, call func(context.Context) (any, error)) (any, error) {
+	G.lock.Lock()
+	if G.flights == nil {
+		G.flights = make(map[string]*flight)
+	}
+	current, ok := G.flights[key]
+	if !ok {
+		flightCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		current = &flight{done: make(chan struct{}), cancel: cancel}
+		G.flights[key] = current
+		go func() {
+			current.value, current.err = call(flightCtx)
+			G.forget(key, current)
+			cancel()
+			close(current.done)
+		}()
+	}
+	current.waiters++
+	G.lock.Unlock()
+	select {
+	case <-current.done:
+		return current.value, current.err
+	case <-ctx.Done():
+		G.lock.Lock()
+		current.waiters--
+		if current.waiters == 0 {
+			current.cancel()
+			G.forgetLocked(key, current)
+		}
+		G.lock.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+func (G *flightGroup) forget(key string, done *flight) {
+	G.lock.Lock()
+	G.forgetLocked(key, done)
+	G.lock.Unlock()
+}
+
+// forgetLocked only removes the flight if a newer one hasn't replaced it.
+func (G *flightGroup) forgetLocked(key string, done *flight) {
+	if G.flights[key] == done {
+		delete(G.flights, key)
+	}
+}
+
+func coalesce[T any](ctx context.Context, client *Client, key string, call func(context.Context) (T, error)) (T, error) {
+	value, err := client.flights.do(ctx, key, func(ctx context.Context) (any, error) {
+		return call(ctx)
+	})
+	typed, _ := value.(T)
+	return typed, err
+}
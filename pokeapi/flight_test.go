@@ -0,0 +1,76 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCoalesceConcurrentFetches(t *testing.T) {
+	var requests atomic.Int32
+	release := make(chan struct{})
+	client := newFakeClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		<-release
+		fakeApiHandler(w, r)
+	})
+	var callers sync.WaitGroup
+	errs := make(chan error, 5)
+	for range 5 {
+		callers.Add(1)
+		go func() {
+			defer callers.Done()
+			_, err := GetPokeDatumByName[Pokemon](context.Background(), client, "pikachu", pokemonEndpoint)
+			errs <- err
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	callers.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+	if actual := requests.Load(); actual != 1 {
+		t.Errorf("actual requests %v did not match expected requests 1", actual)
+	}
+}
+
+func TestCoalesceCallerCancel(t *testing.T) {
+	release := make(chan struct{})
+	client := newFakeClient(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+			return
+		}
+		fakeApiHandler(w, r)
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := make(chan error, 1)
+	go func() {
+		_, err := GetPokeDatumByName[Pokemon](ctx, client, "pikachu", pokemonEndpoint)
+		cancelled <- err
+	}()
+	finished := make(chan error, 1)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		_, err := GetPokeDatumByName[Pokemon](context.Background(), client, "pikachu", pokemonEndpoint)
+		finished <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	if err := <-cancelled; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	close(release)
+	if err := <-finished; err != nil {
+		t.Errorf("remaining caller should still get the pokemon: %v", err)
+	}
+}
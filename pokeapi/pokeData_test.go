@@ -0,0 +1,110 @@
+package pokeapi
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetPokeDataConcurrent(t *testing.T) {
+	var inFlight, maxInFlight, requests atomic.Int32
+	client := newFakeClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			seen := maxInFlight.Load()
+			if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		fakeApiHandler(w, r)
+	})
+	client.Workers = 3
+	areas, err := GetPokeData[Area](context.Background(), client, fakeAreaIds(5, 15), areaEndpoint)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if maxInFlight.Load() > 3 {
+		t.Errorf("%v requests in flight with only 3 workers", maxInFlight.Load())
+	}
+	if maxInFlight.Load() < 2 {
+		t.Errorf("requests were not made concurrently")
+	}
+	for index, area := range areas {
+		if area.Name != fakeAreaNames[5+index] {
+			t.Errorf("actual area '%v' at %v did not match expected area '%v'", area.Name, index, fakeAreaNames[5+index])
+		}
+	}
+
+	requests.Store(0)
+	areas, err = GetPokeData[Area](context.Background(), client, fakeAreaIds(5, 15), areaEndpoint)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if requests.Load() != 0 {
+		t.Errorf("expected cached page to make no requests, made %v", requests.Load())
+	}
+	if len(areas) != 10 {
+		t.Errorf("expected 10 cached areas, got %v", len(areas))
+	}
+}
+
+func TestGetPokeDataFillsGaps(t *testing.T) {
+	var requests atomic.Int32
+	client := newFakeClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		fakeApiHandler(w, r)
+	})
+	if _, err := GetPokeData[Area](context.Background(), client, fakeAreaIds(0, 5), areaEndpoint); err != nil {
+		t.Error(err)
+		return
+	}
+	requests.Store(0)
+	areas, err := GetPokeData[Area](context.Background(), client, fakeAreaIds(0, 10), areaEndpoint)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if requests.Load() != 5 {
+		t.Errorf("expected only the 5 missing areas to be requested, made %v requests", requests.Load())
+	}
+	for index, area := range areas {
+		if area.Name != fakeAreaNames[index] {
+			t.Errorf("actual area '%v' at %v did not match expected area '%v'", area.Name, index, fakeAreaNames[index])
+		}
+	}
+	if fakeStore(client).Len(areaResource) != 10 {
+		t.Errorf("expected 10 areas in the cache without duplicates, got %v", fakeStore(client).Len(areaResource))
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	limiter := NewRateLimiter(100)
+	start := time.Now()
+	for range 5 {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("5 requests at 100/s took only %v", elapsed)
+	}
+	if NewRateLimiter(0).Wait(context.Background()) != nil {
+		t.Errorf("expected an unlimited limiter to never fail")
+	}
+}
+
+func fakeAreaIds(start, end int) []int {
+	var ids []int
+	for index := start; index < end; index++ {
+		ids = append(ids, fakeAreaIdAt(index))
+	}
+	return ids
+}
@@ -1,327 +1,365 @@
 package pokeapi
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"io/fs"
-	"net/http"
-	"os"
-	"path/filepath"
+	"net/url"
+	"path"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 )
 
-// const pokeApiLocationUrl = "https://pokeapi.co/api/v2/location/%v/"
-const pokeApiAreaUrl = "https://pokeapi.co/api/v2/location-area/%v/"
-const pokeApiPokemonUrl = "https://pokeapi.co/api/v2/pokemon/%v/"
+const areaResource = "location-area"
+const areaPageResource = "location-area-page"
 
-// const locationsPath = "pokeLocations.json"
-const areasPath = "pokeapi/pokeAreas.json"
-const pokemonPath = "pokeapi/pokemon/.json"
-
-func checkPokeDataCache[PDT PokeDataType](minIndex, maxIndex int, filePath string) ([]PDT, error) {
-	pokeDataFile, err := os.Open(filePath)
-	if err != nil {
-		return nil, err
-	}
-	defer pokeDataFile.Close()
-	var allPokeData []PDT
-	decoder := json.NewDecoder(pokeDataFile)
-	if err := decoder.Decode(&allPokeData); err != nil {
-		return nil, err
-	}
-	var pokeData []PDT
-	for _, pokeDatum := range allPokeData {
-		if pokeDatum.GetID()-1 >= minIndex && pokeDatum.GetID()-1 <= maxIndex {
-			pokeData = append(pokeData, pokeDatum)
-		}
+func loadPokeDatum[PDT PokeDataType](data []byte, ok bool) (PDT, bool) {
+	var pokeDatum PDT
+	if !ok {
+		return pokeDatum, false
 	}
-
-	if len(pokeData) == 0 {
-		return nil, errors.New("no pokeData in index range")
+	if err := json.Unmarshal(data, &pokeDatum); err != nil {
+		var empty PDT
+		return empty, false
 	}
-	return pokeData, nil
+	return pokeDatum, true
 }
 
-func checkPokeDataCacheByName[PDT PokeDataType](name string, filePath string) (PDT, error) {
-	pokeDataFile, err := os.Open(filePath)
+func StorePokeDatum[PDT PokeDataType](cache Cache, resource string, pokeDatum PDT) error {
+	data, err := json.Marshal(pokeDatum)
 	if err != nil {
-		var pdt PDT
-		return pdt, err
-	}
-	defer pokeDataFile.Close()
-	var allPokeData []PDT
-	decoder := json.NewDecoder(pokeDataFile)
-	if err := decoder.Decode(&allPokeData); err != nil {
-		var pdt PDT
-		return pdt, err
-	}
-	var pokeDatumMatch PDT
-	pokeMatch := false
-	for _, pokeDatum := range allPokeData {
-		if pokeDatum.GetName() == name {
-			pokeDatumMatch = pokeDatum
-			pokeMatch = true
-			break
-		}
-	}
-
-	if !pokeMatch {
-		var pdt PDT
-		return pdt, errors.New("no pokeData in index range")
+		return err
 	}
-	return pokeDatumMatch, nil
+	return cache.Put(resource, pokeDatum.GetID(), pokeDatum.GetName(), data)
 }
 
-func GetPokeDatum[PDT PokeDataType](id int, url, filePath string, cacheChecked bool) (PDT, error) {
-	if !cacheChecked {
-		cachePokeData, err := checkPokeDataCache[PDT](id-1, id-1, filePath)
-		if err == nil {
-			return cachePokeData[0], nil
+// cachedPokeDatum reads a resource through the client's cache, which calls
+// fetch when no layer has it and to refresh it once stale. Cached data that
+// no longer decodes is fetched again.
+func cachedPokeDatum[PDT PokeDataType](ctx context.Context, client *Client, resource string, id int, fetch func(context.Context) (PDT, error)) (PDT, error) {
+	data, err := client.cache().Load(ctx, resource, id, func(ctx context.Context) ([]byte, error) {
+		pokeDatum, err := fetch(ctx)
+		if err != nil {
+			return nil, err
 		}
-	}
-	currentUrl := fmt.Sprintf(url, id)
-	getResult, err := http.Get(currentUrl)
+		return json.Marshal(pokeDatum)
+	})
 	if err != nil {
 		var empty PDT
 		return empty, err
 	}
-	defer getResult.Body.Close()
-	var pokeDatum PDT
-	decoder := json.NewDecoder(getResult.Body)
-	if err := decoder.Decode(&pokeDatum); err != nil {
-		var empty PDT
-		return empty, err
+	if pokeDatum, ok := loadPokeDatum[PDT](data, true); ok {
+		return pokeDatum, nil
 	}
-	return pokeDatum, nil
+	return fetch(ctx)
 }
 
-func GetPokeDatumByName[PDT PokeDataType](name string, url, filePath string, cacheChecked bool) (PDT, error) {
-	if !cacheChecked {
-		pokeDatum, err := checkPokeDataCacheByName[PDT](name, filePath)
-		if err == nil {
-			return pokeDatum, nil
-		}
+func GetPokeDatum[PDT PokeDataType](ctx context.Context, client *Client, id int, endpoint string) (PDT, error) {
+	return cachedPokeDatum(ctx, client, endpointResource(endpoint), id, func(ctx context.Context) (PDT, error) {
+		return fetchPokeDatum[PDT](ctx, client, strconv.Itoa(id), endpoint)
+	})
+}
+
+func GetPokeDatumByName[PDT PokeDataType](ctx context.Context, client *Client, name string, endpoint string) (PDT, error) {
+	resource := endpointResource(endpoint)
+	fetch := func(ctx context.Context) (PDT, error) {
+		return fetchPokeDatum[PDT](ctx, client, name, endpoint)
 	}
-	currentUrl := fmt.Sprintf(url, name)
-	getResult, err := http.Get(currentUrl)
-	if err != nil {
-		var empty PDT
-		return empty, err
+	id, ok := client.cache().Lookup(resource, name)
+	if !ok {
+		return fetch(ctx)
 	}
-	defer getResult.Body.Close()
-	var pokeDatum PDT
-	decoder := json.NewDecoder(getResult.Body)
-	if err := decoder.Decode(&pokeDatum); err != nil {
-		var empty PDT
-		return empty, err
-	}
-	return pokeDatum, nil
+	return cachedPokeDatum(ctx, client, resource, id, fetch)
 }
 
-func getPokeIdByName(name, filePath string) int {
-	pokeId := 0
-	for i := 0; ; i++ {
-		currentPath := getCurrentPath(filePath, strconv.Itoa(i))
-		currentPath = getCurrentPath(currentPath, "id")
-		pokeNameId, err := checkPokeDataCacheByName[PokeNameId](name, currentPath)
+// fetchPokeDatum fetches and stores a resource by id or name, sharing the
+// fetch and the store write with any identical request already in flight.
+// An id and a name for the same resource are still fetched separately.
+func fetchPokeDatum[PDT PokeDataType](ctx context.Context, client *Client, key string, endpoint string) (PDT, error) {
+	resource := endpointResource(endpoint)
+	return coalesce(ctx, client, resource+"/"+key, func(ctx context.Context) (PDT, error) {
+		pokeDatum, err := fetchJson[PDT](ctx, client, endpoint, key)
 		if err != nil {
-			if !errors.Is(err, errors.New("no pokeData in index range")) {
-				break
+			return pokeDatum, err
+		}
+		return pokeDatum, StorePokeDatum(client.cache(), resource, pokeDatum)
+	})
+}
+
+// GetMissingPokeData fetches the ids with a bounded pool of workers, keeping
+// the results in the same order as the ids.
+func GetMissingPokeData[PDT PokeDataType](ctx context.Context, client *Client, missingPokeData []int, endpoint string) ([]PDT, error) {
+	pokeData := make([]PDT, len(missingPokeData))
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var firstErr error
+	var errOnce sync.Once
+	var workers sync.WaitGroup
+	jobs := make(chan int)
+	for range min(max(client.Workers, 1), len(missingPokeData)) {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for index := range jobs {
+				pokeDatum, err := GetPokeDatum[PDT](ctx, client, missingPokeData[index], endpoint)
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					continue
+				}
+				pokeData[index] = pokeDatum
 			}
-			continue
+		}()
+	}
+sendJobs:
+	for index := range missingPokeData {
+		select {
+		case jobs <- index:
+		case <-ctx.Done():
+			break sendJobs
 		}
-		pokeId = pokeNameId.Id
-		break
 	}
-	return pokeId
+	close(jobs)
+	workers.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return pokeData, nil
 }
 
-func GetMissingPokeData[PDT PokeDataType](missingPokeData []int, url, filePath string) ([]PDT, error) {
-	pokeData := make([]PDT, len(missingPokeData))
-	for index, id := range missingPokeData {
-		pokeDatum, err := GetPokeDatum[PDT](id, url, filePath, true)
+func GetPokeData[PDT PokeDataType](ctx context.Context, client *Client, ids []int, endpoint string) ([]PDT, error) {
+	resource := endpointResource(endpoint)
+	pokeData := make([]PDT, len(ids))
+	var missingPokeDataIds []int
+	for index, id := range ids {
+		if !client.cache().Has(resource, id) {
+			missingPokeDataIds = append(missingPokeDataIds, id)
+			continue
+		}
+		pokeDatum, err := GetPokeDatum[PDT](ctx, client, id, endpoint)
 		if err != nil {
 			return nil, err
 		}
 		pokeData[index] = pokeDatum
 	}
+	if len(missingPokeDataIds) == 0 {
+		return pokeData, nil
+	}
+	missingPokeData, err := GetMissingPokeData[PDT](ctx, client, missingPokeDataIds, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	for index, pokeDatum := range missingPokeData {
+		pokeData[slices.Index(ids, missingPokeDataIds[index])] = pokeDatum
+	}
 	return pokeData, nil
 }
 
-func CachePokeData[PDT PokeDataType](pokeData []PDT, filePath string, hasIdmap bool) error {
-	var cachedPokeData []PDT
-	var cachedPokeDataNames []PokeNameId
-	fileIdPath := getCurrentPath(filePath, "id")
-	pokeDataFile, err := os.Open(filePath)
-	if err == nil {
-		decoder := json.NewDecoder(pokeDataFile)
-		if err := decoder.Decode(&cachedPokeData); err != nil {
-			pokeDataFile.Close()
-			return err
-		}
-		pokeDataFile.Close()
-		if hasIdmap {
-			pokeNameIdFile, err := os.Open(fileIdPath)
-			if err != nil {
-				return err
-			}
-			decoder = json.NewDecoder(pokeNameIdFile)
-			if err := decoder.Decode(&cachedPokeDataNames); err != nil {
-				pokeDataFile.Close()
-				return err
-			}
-			pokeNameIdFile.Close()
-		}
+func (C *Client) FirstAreaPageUrl() string {
+	return fmt.Sprintf(C.endpointUrl(areaListEndpoint), 0, areaPageLimit)
+}
 
-	}
+// GetAreaPage lists one page of location area names from the PokeAPI list
+// endpoint, leaving the areas themselves to be fetched when explored. Pages
+// are cached by offset, since the PokeAPI's next and previous urls are not
+// written the same way as FirstAreaPageUrl.
+func (C *Client) GetAreaPage(ctx context.Context, pageUrl string) (AreaPage, error) {
+	if pageUrl == "" {
+		pageUrl = C.FirstAreaPageUrl()
+	}
+	offset, cacheable := areaPageOffset(pageUrl)
+	if !cacheable {
+		return C.fetchAreaPage(ctx, pageUrl, offset, false)
+	}
+	return cachedPokeDatum(ctx, C, areaPageResource, offset+1, func(ctx context.Context) (AreaPage, error) {
+		return C.fetchAreaPage(ctx, pageUrl, offset, true)
+	})
+}
 
-	cachedPokeData = append(cachedPokeData, pokeData...)
-	if hasIdmap {
-		for _, pokeDatum := range pokeData {
-			pokeNameId := PokeNameId{pokeDatum.GetName(), pokeDatum.GetID()}
-			cachedPokeDataNames = append(cachedPokeDataNames, pokeNameId)
-		}
+func (C *Client) fetchAreaPage(ctx context.Context, pageUrl string, offset int, cacheable bool) (AreaPage, error) {
+	areaList, err := fetchUrlJson[NamedResourceList](ctx, C, pageUrl, areaResource, "")
+	if err != nil {
+		return AreaPage{}, err
 	}
-
-	if errors.Is(err, fs.ErrNotExist) {
-		dirSep := strings.LastIndex(filePath, "/")
-		os.MkdirAll(filePath[:dirSep], 0755)
+	page := AreaPage{Offset: offset, Url: pageUrl, Next: derefString(areaList.Next), Previous: derefString(areaList.Previous)}
+	for _, result := range areaList.Results {
+		page.Names = append(page.Names, result.Name)
+	}
+	if !cacheable {
+		return page, nil
 	}
+	return page, StorePokeDatum(C.cache(), areaPageResource, page)
+}
 
-	pokeDataFile, err = os.Create(filePath)
+// areaPageOffset reads the offset from a page url. Only pages of
+// areaPageLimit areas can be cached by it, any other page is always fetched.
+func areaPageOffset(pageUrl string) (int, bool) {
+	pageQuery, err := url.Parse(pageUrl)
 	if err != nil {
-		return err
+		return 0, false
 	}
-	defer pokeDataFile.Close()
-	encoder := json.NewEncoder(pokeDataFile)
-	if err := encoder.Encode(cachedPokeData); err != nil {
-		return err
+	query := pageQuery.Query()
+	offset, err := strconv.Atoi(query.Get("offset"))
+	if err != nil && query.Get("offset") != "" {
+		return 0, false
 	}
-	if hasIdmap {
-		pokeNameDataFile, err := os.Create(fileIdPath)
-		if err != nil {
-			return err
-		}
-		defer pokeNameDataFile.Close()
-		encoder = json.NewEncoder(pokeNameDataFile)
-		if err := encoder.Encode(cachedPokeDataNames); err != nil {
-			return err
-		}
+	limit, err := strconv.Atoi(query.Get("limit"))
+	if err != nil || limit != areaPageLimit {
+		return offset, false
 	}
-	return nil
+	return offset, true
 }
 
-func GetPokeData[PDT PokeDataType](minIndex, maxIndex int, url, filePath string) ([]PDT, error) {
-	cachedPokeData, err := checkPokeDataCache[PDT](minIndex, maxIndex, filePath)
-	if err == nil {
-		if len(cachedPokeData) == maxIndex-minIndex+1 {
-			return cachedPokeData, nil
-		}
-		allIds := make([]int, maxIndex-minIndex+1)
-		for _, pokeDatum := range cachedPokeData {
-			curId := pokeDatum.GetID()
-			allIds[curId-1] = curId
-		}
-		var missingPokeDataIds []int
-		for index, id := range allIds {
-			if id == 0 {
-				missingPokeDataIds = append(missingPokeDataIds, index+1)
-			}
-		}
-		missingPokeData, err := GetMissingPokeData[PDT](missingPokeDataIds, url, filePath)
-		if err != nil {
-			return nil, err
-		}
-		if err := CachePokeData(missingPokeData, filePath, true); err != nil {
-			return nil, err
-		}
+func resourceUrlId(resourceUrl string) (int, error) {
+	idString := path.Base(strings.TrimSuffix(resourceUrl, "/"))
+	id, err := strconv.Atoi(idString)
+	if err != nil {
+		return 0, fmt.Errorf("no id in resource url %v", resourceUrl)
 	}
-	var pokeData []PDT
-	for i := minIndex + 1; i < maxIndex+2; i++ {
-		pokeDatum, err := GetPokeDatum[PDT](i, url, filePath, true)
-		if err != nil {
-			return nil, err
-		}
-		pokeData = append(pokeData, pokeDatum)
+	return id, nil
+}
+
+func derefString(value *string) string {
+	if value == nil {
+		return ""
 	}
-	err = CachePokeData(pokeData, filePath, true)
+	return *value
+}
+
+func (C *Client) GetRegions(ctx context.Context) ([]string, error) {
+	resourceList, err := C.getResourceList(ctx, endpointResource(regionEndpoint))
 	if err != nil {
 		return nil, err
 	}
-	return pokeData, nil
+	var regionNames []string
+	for _, region := range resourceList.Results {
+		regionNames = append(regionNames, region.Name)
+	}
+	return regionNames, nil
 }
 
-/*func mapifyPokeData[PDT PokeDataType](pokeData []PDT) map[int]PDT {
-	pokeMap := make(map[int]PDT)
-	for _, pokeDatum := range pokeData {
-		pokeMap[pokeDatum.GetID()] = pokeDatum
+func (C *Client) GetRegionLocations(ctx context.Context, regionName string) ([]string, error) {
+	region, err := GetPokeDatumByName[Region](ctx, C, regionName, regionEndpoint)
+	if err != nil {
+		return nil, err
 	}
-	return pokeMap
-}*/
-
-func getCurrentPath(filepath string, fileId string) string {
-	pathExtension := strings.Split(filepath, ".")
-	currentPath := pathExtension[0] + fileId + "." + pathExtension[1]
-	return currentPath
+	var locationNames []string
+	for _, location := range region.Locations {
+		locationNames = append(locationNames, location.Name)
+	}
+	return locationNames, nil
 }
 
-func GetMapStrings(minIndex, maxIndex int) ([]string, error) {
-	currentAreaPath := getCurrentPath(areasPath, strconv.Itoa(minIndex/20))
-	path, err := filepath.Localize(currentAreaPath)
+func (C *Client) GetLocationAreas(ctx context.Context, locationName string) ([]string, error) {
+	location, err := GetPokeDatumByName[Location](ctx, C, locationName, locationEndpoint)
 	if err != nil {
 		return nil, err
 	}
-	areas, err := GetPokeData[Area](minIndex, maxIndex, pokeApiAreaUrl, path)
+	var areaNames []string
+	for _, area := range location.Areas {
+		areaNames = append(areaNames, area.Name)
+	}
+	return areaNames, nil
+}
+
+// GetAreaPokemonVersions lists the pokemon in an area along with the game
+// versions each one can be found in.
+func (C *Client) GetAreaPokemonVersions(ctx context.Context, areaName string) ([]AreaPokemonVersions, error) {
+	var pokemonVersions []AreaPokemonVersions
+	area, err := GetPokeDatumByName[Area](ctx, C, areaName, areaEndpoint)
 	if err != nil {
 		return nil, err
 	}
-	areaNames := make([]string, 20)
-	for index := range areas {
-		areaNames[index] = areas[index].Name
+	for _, ecnounter := range area.PokemonEncounters {
+		pokemon := AreaPokemonVersions{Name: ecnounter.AreaPokemon.Name}
+		for _, versionDetail := range ecnounter.VersionDetails {
+			if !slices.Contains(pokemon.Versions, versionDetail.Version.Name) {
+				pokemon.Versions = append(pokemon.Versions, versionDetail.Version.Name)
+			}
+		}
+		pokemonVersions = append(pokemonVersions, pokemon)
 	}
-	return areaNames, nil
+	return pokemonVersions, nil
 }
 
-func GetPokemonForArea(areaName string) ([]string, error) {
-	id := getPokeIdByName(areaName, areasPath)
+// GetPokemonForArea lists the pokemon in an area, only those found in the
+// given game version unless it is empty.
+func (C *Client) GetPokemonForArea(ctx context.Context, areaName, version string) ([]string, error) {
 	var pokemonNames []string
-	var area Area
-	var err error
-	if id != 0 {
-		area, err = GetPokeDatum[Area](id, pokeApiAreaUrl, getCurrentPath(areasPath, strconv.Itoa(id/20)), false)
-	} else {
-		area, err = GetPokeDatumByName[Area](areaName, pokeApiAreaUrl, "", true)
-	}
+	pokemonVersions, err := C.GetAreaPokemonVersions(ctx, areaName)
 	if err != nil {
 		return nil, err
 	}
-	for _, ecnounter := range area.PokemonEncounters {
-		pokemonNames = append(pokemonNames, ecnounter.AreaPokemon.Name)
+	for _, pokemon := range pokemonVersions {
+		if version == "" || slices.Contains(pokemon.Versions, version) {
+			pokemonNames = append(pokemonNames, pokemon.Name)
+		}
 	}
 	return pokemonNames, nil
 }
 
-func GetPokemonBaseXp(name string) (int, error) {
-	currentpath := getCurrentPath(pokemonPath, name)
-	pokemon, err := GetPokeDatumByName[Pokemon](name, pokeApiPokemonUrl, currentpath, false)
+func (C *Client) GetVersion(ctx context.Context, name string) (GameVersion, error) {
+	return GetPokeDatumByName[GameVersion](ctx, C, name, versionEndpoint)
+}
+
+func (C *Client) GetAreaEncounters(ctx context.Context, areaName string) ([]EncounterSlot, error) {
+	var slots []EncounterSlot
+	area, err := GetPokeDatumByName[Area](ctx, C, areaName, areaEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	for _, encounter := range area.PokemonEncounters {
+		for _, versionDetail := range encounter.VersionDetails {
+			for _, detail := range versionDetail.EncounterDetails {
+				var conditions []string
+				for _, condition := range detail.ConditionValues {
+					conditions = append(conditions, condition.Name)
+				}
+				slots = append(slots, EncounterSlot{
+					Pokemon:    encounter.AreaPokemon.Name,
+					Version:    versionDetail.Version.Name,
+					Method:     detail.Method.Name,
+					Chance:     detail.Chance,
+					MinLevel:   detail.MinLevel,
+					MaxLevel:   detail.MaxLevel,
+					Conditions: conditions,
+				})
+			}
+		}
+	}
+	return slots, nil
+}
+
+// GetPokemonCaptureRate looks up the capture rate of the pokemon's species,
+// which for forms like deoxys-attack is named differently to the pokemon.
+func (C *Client) GetPokemonCaptureRate(ctx context.Context, name string) (int, error) {
+	pokemon, err := GetPokeDatumByName[Pokemon](ctx, C, name, pokemonEndpoint)
+	if err != nil {
+		return 0, err
+	}
+	speciesName := pokemon.Species.Name
+	if speciesName == "" {
+		speciesName = name
+	}
+	species, err := GetPokeDatumByName[Species](ctx, C, speciesName, speciesEndpoint)
 	if err != nil {
 		return 0, err
 	}
-	pokemonData := []Pokemon{pokemon}
-	CachePokeData(pokemonData, currentpath, false)
-	return pokemon.BaseExperience, nil
+	return species.CaptureRate, nil
 }
 
-func GetPokemonStats(name string) (PokemonDescription, error) {
-	currentpath := getCurrentPath(pokemonPath, name)
-	pokemon, err := GetPokeDatumByName[Pokemon](name, pokeApiPokemonUrl, currentpath, false)
+func (C *Client) GetPokemonStats(ctx context.Context, name string) (PokemonDescription, error) {
+	pokemon, err := GetPokeDatumByName[Pokemon](ctx, C, name, pokemonEndpoint)
 	if err != nil {
 		return PokemonDescription{}, err
 	}
-	pokemonData := []Pokemon{pokemon}
-	CachePokeData(pokemonData, currentpath, false)
 
 	var pokeTypes []string
 	for _, pokeType := range pokemon.Types {
@@ -1,10 +1,16 @@
 package pokeapi
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"slices"
+	"sync/atomic"
 	"testing"
 )
 
-func TestGetMapStrings(t *testing.T) {
+func TestGetAreaPage(t *testing.T) {
 	expectedAreas := []string{"canalave-city-area",
 		"eterna-city-area",
 		"pastoria-city-area",
@@ -25,11 +31,13 @@ func TestGetMapStrings(t *testing.T) {
 		"mt-coronet-5f",
 		"mt-coronet-6f",
 		"mt-coronet-1f-from-exterior"}
-	acturalAreas, err := GetMapStrings(0, 19)
+	client := newFakeClient(t, fakeApiHandler)
+	page, err := client.GetAreaPage(context.Background(), client.FirstAreaPageUrl())
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 		return
 	}
+	acturalAreas := page.Names
 	if len(acturalAreas) != len(expectedAreas) {
 		t.Errorf("not enough arreas returned")
 		return
@@ -41,8 +49,106 @@ func TestGetMapStrings(t *testing.T) {
 	}
 }
 
+func TestGetAreaPagePreviousCached(t *testing.T) {
+	var requests atomic.Int32
+	client := newFakeClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		fakeApiHandler(w, r)
+	})
+	page, err := client.GetAreaPage(context.Background(), "")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	page, err = client.GetAreaPage(context.Background(), page.Next)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	requests.Store(0)
+	page, err = client.GetAreaPage(context.Background(), page.Previous)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if page.Offset != 0 || page.Names[0] != fakeAreaNames[0] {
+		t.Errorf("actual page at offset %v starting '%v' did not match the first page", page.Offset, page.Names[0])
+	}
+	if requests.Load() != 0 {
+		t.Errorf("expected the first page from the cache, got %v requests", requests.Load())
+	}
+	if !fakeStore(client).Has(areaPageResource, 1) {
+		t.Errorf("first page not kept in the store")
+	}
+}
+
+func TestGetAreaPageListOnly(t *testing.T) {
+	var requests atomic.Int32
+	client := newFakeClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path != "/location-area" {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		fakeApiHandler(w, r)
+	})
+	page, err := client.GetAreaPage(context.Background(), "")
+	if err != nil {
+		t.Errorf("expected the page from the list alone, got %v", err)
+		return
+	}
+	if len(page.Names) != areaPageLimit {
+		t.Errorf("actual %v areas did not match expected %v areas", len(page.Names), areaPageLimit)
+	}
+	if requests.Load() != 1 {
+		t.Errorf("expected 1 request for a page, got %v", requests.Load())
+	}
+}
+
+func TestGetAreaPageToLastPage(t *testing.T) {
+	client := newFakeClient(t, fakeApiHandler)
+	page, err := client.GetAreaPage(context.Background(), "")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if page.Previous != "" {
+		t.Errorf("first page has a previous page '%v'", page.Previous)
+	}
+	page, err = client.GetAreaPage(context.Background(), page.Next)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	expectedAreas := fakeAreaNames[20:]
+	if len(page.Names) != len(expectedAreas) {
+		t.Errorf("actual areas %v did not match expected areas %v", page.Names, expectedAreas)
+		return
+	}
+	for index := range expectedAreas {
+		if page.Names[index] != expectedAreas[index] {
+			t.Errorf("actual area '%v' did not match expected area '%v", page.Names[index], expectedAreas[index])
+		}
+	}
+	if page.Next != "" {
+		t.Errorf("last page has a next page '%v'", page.Next)
+	}
+	if page.Previous == "" {
+		t.Errorf("last page has no previous page")
+	}
+	pokemon, err := client.GetPokemonForArea(context.Background(), "great-marsh-area-1", "")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(pokemon) != 0 {
+		t.Errorf("unexpected pokemon %v", pokemon)
+	}
+}
+
 func TestGetPokemonForArea(t *testing.T) {
-	actualPokemon, err := GetPokemonForArea("eterna-city-area")
+	client := newFakeClient(t, fakeApiHandler)
+	actualPokemon, err := client.GetPokemonForArea(context.Background(), "eterna-city-area", "")
 	if err != nil {
 		t.Error(err)
 		return
@@ -55,20 +161,63 @@ func TestGetPokemonForArea(t *testing.T) {
 	}
 }
 
-func TestGetPokemonBaseXp(t *testing.T) {
-	baseXp, err := GetPokemonBaseXp("squirtle")
+func TestGetPokemonForAreaVersion(t *testing.T) {
+	client := newFakeClient(t, fakeApiHandler)
+	actualPokemon, err := client.GetPokemonForArea(context.Background(), "eterna-city-area", "pearl")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	expectedPokemon := []string{"psyduck", "magikarp"}
+	if !slices.Equal(actualPokemon, expectedPokemon) {
+		t.Errorf("actual pokemon %v did not match expected pokemon %v", actualPokemon, expectedPokemon)
+	}
+}
+
+func TestGetVersion(t *testing.T) {
+	client := newFakeClient(t, fakeApiHandler)
+	if _, err := client.GetVersion(context.Background(), "platinum"); err != nil {
+		t.Error(err)
+	}
+	if _, err := client.GetVersion(context.Background(), "plat"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestRegionNavigation(t *testing.T) {
+	client := newFakeClient(t, fakeApiHandler)
+	regions, err := client.GetRegions(context.Background())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !slices.Equal(regions, fakeRegionNames) {
+		t.Errorf("actual regions %v did not match expected regions %v", regions, fakeRegionNames)
+	}
+	locations, err := client.GetRegionLocations(context.Background(), "sinnoh")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !slices.Equal(locations, fakeRegions["sinnoh"]) {
+		t.Errorf("actual locations %v did not match expected locations %v", locations, fakeRegions["sinnoh"])
+	}
+	areas, err := client.GetLocationAreas(context.Background(), "great-marsh")
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	expectedXp := 63
-	if expectedXp != baseXp {
-		t.Errorf("actual Xp %v not equal to expected Xp %v", baseXp, expectedXp)
+	if !slices.Equal(areas, fakeLocations["great-marsh"]) {
+		t.Errorf("actual areas %v did not match expected areas %v", areas, fakeLocations["great-marsh"])
+	}
+	if _, err := client.GetLocationAreas(context.Background(), "viridian-forest"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
 	}
 }
 
 func TestGetPokemonStats(t *testing.T) {
-	pokemonDescription, err := GetPokemonStats("pikachu")
+	client := newFakeClient(t, fakeApiHandler)
+	pokemonDescription, err := client.GetPokemonStats(context.Background(), "pikachu")
 	if err != nil {
 		t.Error(err)
 		return
@@ -101,3 +250,40 @@ func TestGetPokemonStats(t *testing.T) {
 		t.Errorf("worng Types %v", pokemonDescription.Types)
 	}
 }
+
+func TestGetPokemonCaptureRate(t *testing.T) {
+	client := newFakeClient(t, fakeApiHandler)
+	captureRate, err := client.GetPokemonCaptureRate(context.Background(), "pikachu")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if captureRate != 190 {
+		t.Errorf("actual capture rate %v not equal to expected capture rate 190", captureRate)
+	}
+}
+
+func TestGetAreaEncounters(t *testing.T) {
+	client := newFakeClient(t, fakeApiHandler)
+	slots, err := client.GetAreaEncounters(context.Background(), "eterna-city-area")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	expectedCount := len(fakeEncounters)
+	for _, versions := range fakeEncounterVersions {
+		expectedCount += len(versions)
+	}
+	if len(slots) != expectedCount {
+		t.Errorf("actual slot count %v did not match expected count %v", len(slots), expectedCount)
+		return
+	}
+	expected := EncounterSlot{Pokemon: "golduck", Version: "diamond", Method: "surf", Chance: 10, MinLevel: 20, MaxLevel: 40}
+	if !reflect.DeepEqual(slots[2], expected) {
+		t.Errorf("actual slot %v did not match expected slot %v", slots[2], expected)
+	}
+	gyarados := slots[len(slots)-3]
+	if gyarados.Pokemon != "gyarados" || !slices.Equal(gyarados.Conditions, []string{"time-night"}) {
+		t.Errorf("actual slot %v did not have expected conditions [time-night]", gyarados)
+	}
+}
@@ -1,21 +1,26 @@
 package pokeapi
 
+import "strconv"
+
 type PokeDataType interface {
 	GetID() int
 	GetName() string
 }
 
-type PokeNameId struct {
-	Name string `json:"name"`
-	Id   int    `json:"id"`
+type Region struct {
+	ID             int             `json:"id"`
+	Name           string          `json:"name"`
+	Locations      []NamedResource `json:"locations"`
+	MainGeneration NamedResource   `json:"main_generation"`
+	VersionGroups  []NamedResource `json:"version_groups"`
 }
 
-func (P PokeNameId) GetID() int {
-	return P.Id
+func (R Region) GetID() int {
+	return R.ID
 }
 
-func (P PokeNameId) GetName() string {
-	return P.Name
+func (R Region) GetName() string {
+	return R.Name
 }
 
 type Location struct {
@@ -53,6 +58,22 @@ func (L Location) GetName() string {
 	return L.Name
 }
 
+type AreaPage struct {
+	Offset   int      `json:"offset"`
+	Url      string   `json:"url"`
+	Names    []string `json:"names"`
+	Next     string   `json:"next"`
+	Previous string   `json:"previous"`
+}
+
+func (A AreaPage) GetID() int {
+	return A.Offset + 1
+}
+
+func (A AreaPage) GetName() string {
+	return strconv.Itoa(A.Offset)
+}
+
 type AreaPokemon struct {
 	Name string `json:"name"`
 	URL  string `json:"-"`
@@ -121,6 +142,23 @@ func (A Area) GetName() string {
 	return A.Name
 }
 
+type AreaPokemonVersions struct {
+	Name     string
+	Versions []string
+}
+
+// EncounterSlot is one way of meeting a pokemon in an area, flattened out of
+// Area.PokemonEncounters.
+type EncounterSlot struct {
+	Pokemon    string
+	Version    string
+	Method     string
+	Chance     int
+	MinLevel   int
+	MaxLevel   int
+	Conditions []string
+}
+
 type HeldItem struct {
 	Item struct {
 		Name string `json:"name"`
@@ -417,3 +455,84 @@ type PokemonDescription struct {
 	PokemonStats `json:"pokemon_stats"`
 	Types        []string `json:"types"`
 }
+
+// ResourceList is every name of one resource type, cached as the only
+// entry of its own "<resource>-list" resource.
+type ResourceList struct {
+	NamedResourceList
+}
+
+func (R ResourceList) GetID() int {
+	return 1
+}
+
+func (R ResourceList) GetName() string {
+	return ""
+}
+
+type NamedResource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+type NamedResourceList struct {
+	Count    int             `json:"count"`
+	Next     *string         `json:"next"`
+	Previous *string         `json:"previous"`
+	Results  []NamedResource `json:"results"`
+}
+
+type Species struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	CaptureRate int    `json:"capture_rate"`
+	IsLegendary bool   `json:"is_legendary"`
+	IsMythical  bool   `json:"is_mythical"`
+}
+
+func (S Species) GetID() int {
+	return S.ID
+}
+
+func (S Species) GetName() string {
+	return S.Name
+}
+
+type GameVersion struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	VersionGroup struct {
+		Name string `json:"name"`
+		URL  string `json:"-"`
+	} `json:"version_group"`
+}
+
+func (G GameVersion) GetID() int {
+	return G.ID
+}
+
+func (G GameVersion) GetName() string {
+	return G.Name
+}
+
+type PokeType struct {
+	ID              int           `json:"id"`
+	Name            string        `json:"name"`
+	Generation      NamedResource `json:"generation"`
+	DamageRelations struct {
+		DoubleDamageFrom []NamedResource `json:"double_damage_from"`
+		DoubleDamageTo   []NamedResource `json:"double_damage_to"`
+		HalfDamageFrom   []NamedResource `json:"half_damage_from"`
+		HalfDamageTo     []NamedResource `json:"half_damage_to"`
+		NoDamageFrom     []NamedResource `json:"no_damage_from"`
+		NoDamageTo       []NamedResource `json:"no_damage_to"`
+	} `json:"damage_relations"`
+}
+
+func (P PokeType) GetID() int {
+	return P.ID
+}
+
+func (P PokeType) GetName() string {
+	return P.Name
+}
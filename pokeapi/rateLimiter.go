@@ -0,0 +1,40 @@
+package pokeapi
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter spaces requests evenly so concurrent fetches stay polite to
+// the PokeAPI. A nil RateLimiter never waits.
+type RateLimiter struct {
+	interval time.Duration
+	next     time.Time
+	lock     sync.Mutex
+}
+
+func NewRateLimiter(requestsPerSecond float64) *RateLimiter {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+	return &RateLimiter{interval: time.Duration(float64(time.Second) / requestsPerSecond)}
+}
+
+func (R *RateLimiter) Wait(ctx context.Context) error {
+	if R == nil {
+		return nil
+	}
+	R.lock.Lock()
+	now := time.Now()
+	if R.next.Before(now) {
+		R.next = now
+	}
+	wait := R.next.Sub(now)
+	R.next = R.next.Add(R.interval)
+	R.lock.Unlock()
+	if wait <= 0 {
+		return nil
+	}
+	return sleepContext(ctx, wait)
+}
@@ -0,0 +1,59 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+)
+
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{MaxAttempts: 3, BaseDelay: 250 * time.Millisecond, MaxDelay: 5 * time.Second}
+
+// delay backs off exponentially with jitter, unless the server told us how
+// long to wait with a Retry-After header. A Retry-After over MaxDelay is not
+// worth waiting for, so delay reports false to give up instead.
+func (R RetryPolicy) delay(attempt int, err error) (time.Duration, bool) {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && statusErr.RetryAfter > 0 {
+		if R.MaxDelay > 0 && statusErr.RetryAfter > R.MaxDelay {
+			return 0, false
+		}
+		return statusErr.RetryAfter, true
+	}
+	backoff := R.BaseDelay << (attempt - 1)
+	if backoff <= 0 || (R.MaxDelay > 0 && backoff > R.MaxDelay) {
+		backoff = R.MaxDelay
+	}
+	if backoff <= 0 {
+		return 0, true
+	}
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1)), true
+}
+
+func retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return errors.Is(err, ErrRateLimited) || errors.Is(err, ErrServer)
+	}
+	return !errors.Is(err, ErrDecode)
+}
+
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
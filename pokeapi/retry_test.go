@@ -0,0 +1,128 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func failingHandler(failures int32, status int, retryAfter string) (http.HandlerFunc, *atomic.Int32) {
+	var requests atomic.Int32
+	return func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= failures {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			w.WriteHeader(status)
+			return
+		}
+		fakeApiHandler(w, r)
+	}, &requests
+}
+
+func TestRetryServerError(t *testing.T) {
+	handler, requests := failingHandler(2, http.StatusServiceUnavailable, "")
+	client := newFakeClient(t, handler)
+	client.Retry = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+	pokemonDescription, err := client.GetPokemonStats(context.Background(), "squirtle")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if pokemonDescription.Hp != 44 {
+		t.Errorf("actual Hp %v not equal to expected Hp 44", pokemonDescription.Hp)
+	}
+	if requests.Load() != 3 {
+		t.Errorf("expected 3 requests, got %v", requests.Load())
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	handler, requests := failingHandler(5, http.StatusInternalServerError, "")
+	client := newFakeClient(t, handler)
+	client.Retry = RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+	_, err := client.GetPokemonStats(context.Background(), "squirtle")
+	if !errors.Is(err, ErrServer) {
+		t.Errorf("expected ErrServer, got %v", err)
+	}
+	if requests.Load() != 2 {
+		t.Errorf("expected 2 requests, got %v", requests.Load())
+	}
+}
+
+func TestRetryNotFoundNotRetried(t *testing.T) {
+	handler, requests := failingHandler(0, 0, "")
+	client := newFakeClient(t, handler)
+	client.Retry = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+	_, err := client.GetPokemonStats(context.Background(), "pikachuu")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if requests.Load() != 1 {
+		t.Errorf("expected 1 request, got %v", requests.Load())
+	}
+}
+
+func TestRetryAfterHonoured(t *testing.T) {
+	handler, requests := failingHandler(1, http.StatusTooManyRequests, "1")
+	client := newFakeClient(t, handler)
+	client.Retry = RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxDelay: 2 * time.Second}
+	start := time.Now()
+	if _, err := client.GetPokemonStats(context.Background(), "squirtle"); err != nil {
+		t.Error(err)
+		return
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %v, before the requested Retry-After of 1s", elapsed)
+	}
+	if requests.Load() != 2 {
+		t.Errorf("expected 2 requests, got %v", requests.Load())
+	}
+}
+
+func TestRetryCancelledWhileWaiting(t *testing.T) {
+	handler, _ := failingHandler(1, http.StatusTooManyRequests, "60")
+	client := newFakeClient(t, handler)
+	client.Retry = RetryPolicy{MaxAttempts: 2, MaxDelay: 2 * time.Minute}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := client.GetPokemonStats(ctx, "squirtle")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestRetryAfterOverMaxDelay(t *testing.T) {
+	handler, requests := failingHandler(1, http.StatusTooManyRequests, "60")
+	client := newFakeClient(t, handler)
+	client.Retry = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Second}
+	start := time.Now()
+	_, err := client.GetPokemonStats(context.Background(), "squirtle")
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.RetryAfter != time.Minute {
+		t.Errorf("expected a StatusError asking to retry after 1m, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("waited %v instead of giving up", elapsed)
+	}
+	if requests.Load() != 1 {
+		t.Errorf("expected 1 request, got %v", requests.Load())
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond}
+	for attempt := 1; attempt <= 4; attempt++ {
+		expected := min(policy.BaseDelay<<(attempt-1), policy.MaxDelay)
+		delay, ok := policy.delay(attempt, errors.New("network"))
+		if !ok {
+			t.Errorf("attempt %v: network error not retried", attempt)
+		}
+		if delay < expected/2 || delay > expected {
+			t.Errorf("attempt %v: delay %v outside [%v, %v]", attempt, delay, expected/2, expected)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package pokeapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/asrioth/pokedexcli/pokeDirs"
+)
+
+const storeIndexFile = "index.jsonl"
+
+type storeIndexEntry struct {
+	Resource string `json:"resource"`
+	Id       int    `json:"id"`
+	Name     string `json:"name"`
+}
+
+type resourceIndex struct {
+	names map[string]int
+	ids   map[int]string
+}
+
+// Store is the on-disk Cache of PokeAPI resources. Every resource is kept in
+// its own file keyed by type and id, and an append-only index maps names to
+// ids so lookups never have to scan the cached data. A nil Store caches
+// nothing.
+type Store struct {
+	// TTLs is how long a resource type's files are used before Load refreshes
+	// them, DefaultTTL for types without one. Files are never deleted, so an
+	// offline client still has them.
+	TTLs       map[string]time.Duration
+	dir        string
+	indexes    map[string]*resourceIndex
+	tornIndex  bool
+	refreshing map[string]bool
+	lock       sync.RWMutex
+}
+
+func OpenStore(dir string) (*Store, error) {
+	store := Store{dir: dir, indexes: make(map[string]*resourceIndex), refreshing: make(map[string]bool)}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	index, err := os.ReadFile(filepath.Join(dir, storeIndexFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return &store, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range bytes.Split(index, []byte("\n")) {
+		var entry storeIndexEntry
+		// a line torn by a crash mid-append is skipped, its data file is
+		// simply fetched again
+		if err := json.Unmarshal(line, &entry); err != nil {
+			continue
+		}
+		store.index(entry.Resource).add(entry.Id, entry.Name)
+	}
+	store.tornIndex = len(index) > 0 && index[len(index)-1] != '\n'
+	return &store, nil
+}
+
+func (S *Store) Dir() string {
+	if S == nil {
+		return ""
+	}
+	return S.dir
+}
+
+func (S *Store) index(resource string) *resourceIndex {
+	index, ok := S.indexes[resource]
+	if !ok {
+		index = &resourceIndex{names: make(map[string]int), ids: make(map[int]string)}
+		S.indexes[resource] = index
+	}
+	return index
+}
+
+func (R *resourceIndex) add(id int, name string) {
+	if oldName, ok := R.ids[id]; ok && oldName != name {
+		delete(R.names, oldName)
+	}
+	R.ids[id] = name
+	if name != "" {
+		R.names[name] = id
+	}
+}
+
+func (S *Store) dataPath(resource string, id int) string {
+	return filepath.Join(S.dir, resource, strconv.Itoa(id)+".json")
+}
+
+func (S *Store) Get(resource string, id int) ([]byte, bool) {
+	if S == nil {
+		return nil, false
+	}
+	S.lock.RLock()
+	index, ok := S.indexes[resource]
+	if ok {
+		_, ok = index.ids[id]
+	}
+	S.lock.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	data, err := os.ReadFile(S.dataPath(resource, id))
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+func (S *Store) Lookup(resource, name string) (int, bool) {
+	if S == nil {
+		return 0, false
+	}
+	S.lock.RLock()
+	defer S.lock.RUnlock()
+	index, ok := S.indexes[resource]
+	if !ok {
+		return 0, false
+	}
+	id, ok := index.names[name]
+	return id, ok
+}
+
+func (S *Store) Load(ctx context.Context, resource string, id int, fetch func(context.Context) ([]byte, error)) ([]byte, error) {
+	data, ok := S.Get(resource, id)
+	if !ok {
+		return fetch(ctx)
+	}
+	info, err := os.Stat(S.dataPath(resource, id))
+	if err != nil || time.Since(info.ModTime()) >= resourceTTL(S.TTLs, resource) {
+		S.refresh(ctx, resource, id, fetch)
+	}
+	return data, nil
+}
+
+// refresh fetches a stale file again in the background, once however many
+// times it is loaded meanwhile. fetch stores what it gets through the
+// client's cache, so the result is dropped here.
+func (S *Store) refresh(ctx context.Context, resource string, id int, fetch func(context.Context) ([]byte, error)) {
+	key := resource + "/" + strconv.Itoa(id)
+	S.lock.Lock()
+	defer S.lock.Unlock()
+	if S.refreshing[key] {
+		return
+	}
+	S.refreshing[key] = true
+	go func() {
+		fetch(context.WithoutCancel(ctx))
+		S.lock.Lock()
+		delete(S.refreshing, key)
+		S.lock.Unlock()
+	}()
+}
+
+func (S *Store) GetByName(resource, name string) ([]byte, bool) {
+	return getByName(S, resource, name)
+}
+
+func (S *Store) Has(resource string, id int) bool {
+	if S == nil {
+		return false
+	}
+	S.lock.RLock()
+	defer S.lock.RUnlock()
+	index, ok := S.indexes[resource]
+	if !ok {
+		return false
+	}
+	_, ok = index.ids[id]
+	return ok
+}
+
+func (S *Store) Len(resource string) int {
+	if S == nil {
+		return 0
+	}
+	S.lock.RLock()
+	defer S.lock.RUnlock()
+	index, ok := S.indexes[resource]
+	if !ok {
+		return 0
+	}
+	return len(index.ids)
+}
+
+// Put writes the data atomically, replacing any earlier copy of the same
+// resource, and only grows the index the first time an id/name is seen. An
+// empty name keeps the name already indexed for the id.
+func (S *Store) Put(resource string, id int, name string, data []byte) error {
+	if S == nil {
+		return nil
+	}
+	dataPath := S.dataPath(resource, id)
+	if err := os.MkdirAll(filepath.Dir(dataPath), 0755); err != nil {
+		return err
+	}
+	if err := pokeDirs.WriteFileAtomic(dataPath, data); err != nil {
+		return err
+	}
+	S.lock.Lock()
+	defer S.lock.Unlock()
+	index := S.index(resource)
+	if oldName, ok := index.ids[id]; ok && (oldName == name || name == "") {
+		return nil
+	}
+	line, err := json.Marshal(storeIndexEntry{resource, id, name})
+	if err != nil {
+		return err
+	}
+	indexFile, err := os.OpenFile(filepath.Join(S.dir, storeIndexFile), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer indexFile.Close()
+	if S.tornIndex {
+		line = append([]byte("\n"), line...)
+	}
+	if _, err := indexFile.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("index %v %v: %w", resource, id, err)
+	}
+	S.tornIndex = false
+	index.add(id, name)
+	return nil
+}
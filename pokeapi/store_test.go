@@ -0,0 +1,124 @@
+package pokeapi
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestStorePutGet(t *testing.T) {
+	store, err := OpenStore(t.TempDir())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if err := store.Put("pokemon", 25, "pikachu", []byte(`{"id":25}`)); err != nil {
+		t.Error(err)
+		return
+	}
+	data, ok := store.Get("pokemon", 25)
+	if !ok || string(data) != `{"id":25}` {
+		t.Errorf("get by id returned '%s' %v", data, ok)
+	}
+	data, ok = store.GetByName("pokemon", "pikachu")
+	if !ok || string(data) != `{"id":25}` {
+		t.Errorf("get by name returned '%s' %v", data, ok)
+	}
+	if _, ok := store.Get("location-area", 25); ok {
+		t.Errorf("found pokemon 25 under another resource type")
+	}
+	if _, ok := store.GetByName("pokemon", "raichu"); ok {
+		t.Errorf("found a pokemon that was never stored")
+	}
+}
+
+func TestStoreNoDuplicates(t *testing.T) {
+	dir := t.TempDir()
+	store, err := OpenStore(dir)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for range 3 {
+		if err := store.Put("pokemon", 7, "squirtle", []byte(`{"id":7}`)); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	if err := store.Put("pokemon", 7, "squirtle", []byte(`{"id":7,"height":5}`)); err != nil {
+		t.Error(err)
+		return
+	}
+	index, err := os.ReadFile(filepath.Join(dir, storeIndexFile))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if lines := bytes.Count(index, []byte("\n")); lines != 1 {
+		t.Errorf("expected 1 index entry, got %v", lines)
+	}
+	if store.Len("pokemon") != 1 {
+		t.Errorf("expected 1 pokemon stored, got %v", store.Len("pokemon"))
+	}
+	data, _ := store.Get("pokemon", 7)
+	if string(data) != `{"id":7,"height":5}` {
+		t.Errorf("put did not replace earlier data, got '%s'", data)
+	}
+	entries, _ := os.ReadDir(filepath.Join(dir, "pokemon"))
+	for _, entry := range entries {
+		if strings.Contains(entry.Name(), ".tmp") {
+			t.Errorf("temporary file %v left behind", entry.Name())
+		}
+	}
+}
+
+func TestStoreReopen(t *testing.T) {
+	dir := t.TempDir()
+	store, err := OpenStore(dir)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	store.Put("location-area", 2, "eterna-city-area", []byte(`{"id":2}`))
+	store.Put("location-area", 3, "pastoria-city-area", []byte(`{"id":3}`))
+	indexFile, err := os.OpenFile(filepath.Join(dir, storeIndexFile), os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	indexFile.Write([]byte(`{"resource":"location-area","id":4,"na`))
+	indexFile.Close()
+
+	store, err = OpenStore(dir)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if _, ok := store.GetByName("location-area", "eterna-city-area"); !ok {
+		t.Errorf("index was not reloaded")
+	}
+	if store.Len("location-area") != 2 {
+		t.Errorf("expected 2 areas after reopening, got %v", store.Len("location-area"))
+	}
+	store.Put("location-area", 4, "sunyshore-city-area", []byte(`{"id":4}`))
+	store, err = OpenStore(dir)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if _, ok := store.GetByName("location-area", "sunyshore-city-area"); !ok {
+		t.Errorf("entry appended after a torn line was lost")
+	}
+}
+
+func TestNilStore(t *testing.T) {
+	var store *Store
+	if err := store.Put("pokemon", 1, "bulbasaur", []byte("{}")); err != nil {
+		t.Error(err)
+	}
+	if _, ok := store.Get("pokemon", 1); ok {
+		t.Errorf("nil store returned data")
+	}
+}
@@ -0,0 +1,89 @@
+package pokeapi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+const listAllEndpoint = "/%v?limit=%%v"
+const listAllLimit = "100000"
+const listResourceSuffix = "-list"
+
+// getResourceList lists every name of a resource type through the client's
+// cache like any single resource.
+func (C *Client) getResourceList(ctx context.Context, resource string) (NamedResourceList, error) {
+	list, err := cachedPokeDatum(ctx, C, resource+listResourceSuffix, 1, func(ctx context.Context) (ResourceList, error) {
+		resourceList, err := C.fetchResourceList(ctx, resource)
+		return ResourceList{resourceList}, err
+	})
+	return list.NamedResourceList, err
+}
+
+// fetchResourceList lists every name of a resource type from the PokeAPI,
+// replacing any cached list.
+func (C *Client) fetchResourceList(ctx context.Context, resource string) (NamedResourceList, error) {
+	listResource := resource + listResourceSuffix
+	list, err := coalesce(ctx, C, listResource, func(ctx context.Context) (ResourceList, error) {
+		resourceList, err := fetchJson[NamedResourceList](ctx, C, fmt.Sprintf(listAllEndpoint, resource), listAllLimit)
+		if err != nil {
+			return ResourceList{}, err
+		}
+		list := ResourceList{resourceList}
+		return list, StorePokeDatum(C.cache(), listResource, list)
+	})
+	return list.NamedResourceList, err
+}
+
+// SuggestNames returns the names of the given resource type closest to name,
+// for pointing out typos after a not found error.
+func (C *Client) SuggestNames(ctx context.Context, resource, name string, maxSuggestions int) ([]string, error) {
+	resourceList, err := C.getResourceList(ctx, resource)
+	if err != nil {
+		return nil, err
+	}
+	return closestNames(resourceList.Results, name, maxSuggestions), nil
+}
+
+func closestNames(resources []NamedResource, name string, maxSuggestions int) []string {
+	maxDistance := max(2, len(name)/3)
+	type suggestion struct {
+		name     string
+		distance int
+	}
+	var suggestions []suggestion
+	for _, resource := range resources {
+		distance := editDistance(name, resource.Name)
+		if distance <= maxDistance {
+			suggestions = append(suggestions, suggestion{resource.Name, distance})
+		}
+	}
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].distance < suggestions[j].distance
+	})
+	var names []string
+	for index := 0; index < len(suggestions) && index < maxSuggestions; index++ {
+		names = append(names, suggestions[index].name)
+	}
+	return names
+}
+
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			substitution := previous[j-1]
+			if a[i-1] != b[j-1] {
+				substitution++
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, substitution)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,93 @@
+package pokeapi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const syncBatchSize = 50
+
+var SyncResources = []string{areaResource, "pokemon", "pokemon-species", "type"}
+
+// SyncProgress is told how many of a resource's entries are cached so far.
+type SyncProgress func(resource string, done, total int)
+
+// Sync downloads every entry of the resource into the cache. Entries are
+// cached as they arrive, so an interrupted sync picks up where it stopped.
+// The list of entries is fetched again each time, to pick up new ones.
+func (C *Client) Sync(ctx context.Context, resource string, progress SyncProgress) error {
+	switch resource {
+	case areaResource:
+		if err := syncResource[Area](ctx, C, resource, areaEndpoint, progress); err != nil {
+			return err
+		}
+		return C.syncAreaPages(ctx)
+	case "pokemon":
+		return syncResource[Pokemon](ctx, C, resource, pokemonEndpoint, progress)
+	case "pokemon-species":
+		return syncResource[Species](ctx, C, resource, speciesEndpoint, progress)
+	case "type":
+		return syncResource[PokeType](ctx, C, resource, typeEndpoint, progress)
+	}
+	return fmt.Errorf("can't sync %v, try one of %v", resource, strings.Join(SyncResources, ", "))
+}
+
+func syncResource[PDT PokeDataType](ctx context.Context, client *Client, resource, endpoint string, progress SyncProgress) error {
+	resourceList, err := client.fetchResourceList(ctx, resource)
+	if err != nil {
+		return err
+	}
+	done := 0
+	var missingIds []int
+	for _, result := range resourceList.Results {
+		id, err := resourceUrlId(result.URL)
+		if err != nil {
+			return err
+		}
+		if client.cache().Has(resource, id) {
+			done++
+		} else {
+			missingIds = append(missingIds, id)
+		}
+	}
+	progress(resource, done, len(resourceList.Results))
+	for start := 0; start < len(missingIds); start += syncBatchSize {
+		batch := missingIds[start:min(start+syncBatchSize, len(missingIds))]
+		if _, err := GetMissingPokeData[PDT](ctx, client, batch, endpoint); err != nil {
+			return err
+		}
+		done += len(batch)
+		progress(resource, done, len(resourceList.Results))
+	}
+	return nil
+}
+
+// syncAreaPages caches every page map and mapb can show, built from the full
+// list of areas rather than fetching each page.
+func (C *Client) syncAreaPages(ctx context.Context) error {
+	resourceList, err := C.getResourceList(ctx, areaResource)
+	if err != nil {
+		return err
+	}
+	pageUrl := func(offset int) string {
+		return fmt.Sprintf(C.endpointUrl(areaListEndpoint), offset, areaPageLimit)
+	}
+	areas := resourceList.Results
+	for offset := 0; offset < len(areas); offset += areaPageLimit {
+		page := AreaPage{Offset: offset, Url: pageUrl(offset)}
+		for _, area := range areas[offset:min(offset+areaPageLimit, len(areas))] {
+			page.Names = append(page.Names, area.Name)
+		}
+		if offset+areaPageLimit < len(areas) {
+			page.Next = pageUrl(offset + areaPageLimit)
+		}
+		if offset > 0 {
+			page.Previous = pageUrl(max(offset-areaPageLimit, 0))
+		}
+		if err := StorePokeDatum(C.cache(), areaPageResource, page); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSyncResume(t *testing.T) {
+	var requests atomic.Int32
+	client := newFakeClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		fakeApiHandler(w, r)
+	})
+	if _, err := GetPokeData[Area](context.Background(), client, fakeAreaIds(0, 5), areaEndpoint); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err := client.getResourceList(context.Background(), areaResource); err != nil {
+		t.Error(err)
+		return
+	}
+	requests.Store(0)
+	var lastDone, lastTotal int
+	progress := func(resource string, done, total int) {
+		lastDone, lastTotal = done, total
+	}
+	if err := client.Sync(context.Background(), areaResource, progress); err != nil {
+		t.Error(err)
+		return
+	}
+	if lastDone != len(fakeAreaNames) || lastTotal != len(fakeAreaNames) {
+		t.Errorf("actual progress %v/%v did not match expected progress %v/%v", lastDone, lastTotal, len(fakeAreaNames), len(fakeAreaNames))
+	}
+	expectedRequests := int32(len(fakeAreaNames) - 5 + 1)
+	if actual := requests.Load(); actual != expectedRequests {
+		t.Errorf("actual requests %v did not match expected requests %v, cached areas should be skipped and the list refetched", actual, expectedRequests)
+	}
+	requests.Store(0)
+	if err := client.Sync(context.Background(), areaResource, progress); err != nil {
+		t.Error(err)
+		return
+	}
+	if actual := requests.Load(); actual != 1 {
+		t.Errorf("expected a finished sync to only refetch the list, made %v requests", actual)
+	}
+}
+
+func TestSyncOffline(t *testing.T) {
+	client := newFakeClient(t, fakeApiHandler)
+	progress := func(resource string, done, total int) {}
+	for _, resource := range SyncResources {
+		if err := client.Sync(context.Background(), resource, progress); err != nil {
+			t.Errorf("sync %v: %v", resource, err)
+			return
+		}
+	}
+	client.Offline = true
+	page, err := client.GetAreaPage(context.Background(), client.FirstAreaPageUrl())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	page, err = client.GetAreaPage(context.Background(), page.Next)
+	if err != nil || page.Names[0] != fakeAreaNames[areaPageLimit] {
+		t.Errorf("expected the second area page from the cache, got %v (%v)", page.Names, err)
+	}
+	if _, err := client.GetPokemonCaptureRate(context.Background(), "pikachu"); err != nil {
+		t.Error(err)
+	}
+	if _, err := client.GetRegions(context.Background()); !errors.Is(err, ErrOffline) {
+		t.Errorf("expected ErrOffline for an unsynced resource, got %v", err)
+	}
+	if err := client.Sync(context.Background(), "berry", progress); err == nil {
+		t.Errorf("expected an error syncing an unknown resource")
+	}
+}
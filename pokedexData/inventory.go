@@ -0,0 +1,52 @@
+package pokedexData
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrNoBalls = errors.New("none left")
+
+// StarterInventory is what a new trainer, or a save from before inventories,
+// starts out with.
+func StarterInventory() map[string]int {
+	return map[string]int{
+		"poke-ball":   20,
+		"great-ball":  5,
+		"ultra-ball":  2,
+		"master-ball": 1,
+	}
+}
+
+// restockedBalls are the balls Restock tops up, the master ball is a one-off.
+var restockedBalls = []string{"poke-ball", "great-ball", "ultra-ball"}
+
+func (P PokeDex) BallCount(ball string) int {
+	return P.Inventory[ball]
+}
+
+func (P PokeDex) UseBall(ball string) error {
+	if P.Inventory[ball] <= 0 {
+		return fmt.Errorf("%v: %w", ball, ErrNoBalls)
+	}
+	P.Inventory[ball] -= 1
+	return nil
+}
+
+func (P PokeDex) AddBalls(ball string, count int) {
+	P.Inventory[ball] += count
+}
+
+// Restock tops the restocked balls back up to the starter inventory,
+// returning how many of each were added. Balls above it are kept.
+func (P PokeDex) Restock() map[string]int {
+	added := make(map[string]int)
+	starter := StarterInventory()
+	for _, ball := range restockedBalls {
+		if missing := starter[ball] - P.BallCount(ball); missing > 0 {
+			P.AddBalls(ball, missing)
+			added[ball] = missing
+		}
+	}
+	return added
+}
@@ -0,0 +1,75 @@
+package pokedexData
+
+import (
+	"errors"
+	"maps"
+	"testing"
+)
+
+func TestUseBall(t *testing.T) {
+	pokedex := NewPokeDex(t.TempDir())
+	pokedex.Inventory = map[string]int{"great-ball": 1}
+	if err := pokedex.UseBall("great-ball"); err != nil {
+		t.Error(err)
+		return
+	}
+	if count := pokedex.BallCount("great-ball"); count != 0 {
+		t.Errorf("actual great-ball count %v did not match expected count 0", count)
+	}
+	if err := pokedex.UseBall("great-ball"); !errors.Is(err, ErrNoBalls) {
+		t.Errorf("expected ErrNoBalls, got %v", err)
+	}
+	if err := pokedex.UseBall("master-ball"); !errors.Is(err, ErrNoBalls) {
+		t.Errorf("expected ErrNoBalls for a ball never owned, got %v", err)
+	}
+}
+
+func TestInventorySaveLoad(t *testing.T) {
+	dataDir := t.TempDir()
+	pokedex := NewPokeDex(dataDir)
+	pokedex.AddBalls("master-ball", 1)
+	if err := pokedex.UseBall("poke-ball"); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := pokedex.Save(); err != nil {
+		t.Error(err)
+		return
+	}
+	loaded := NewPokeDex(dataDir)
+	if err := loaded.Load(); err != nil {
+		t.Error(err)
+		return
+	}
+	expected := StarterInventory()["poke-ball"] - 1
+	if count := loaded.BallCount("poke-ball"); count != expected {
+		t.Errorf("actual poke-ball count %v did not match expected count %v", count, expected)
+	}
+	if count := loaded.BallCount("master-ball"); count != 2 {
+		t.Errorf("actual master-ball count %v did not match expected count 2", count)
+	}
+}
+
+func TestRestock(t *testing.T) {
+	pokedex := NewPokeDex(t.TempDir())
+	pokedex.Inventory = map[string]int{"poke-ball": 3, "great-ball": 9, "master-ball": 1}
+	added := pokedex.Restock()
+	expectedAdded := map[string]int{"poke-ball": 17, "ultra-ball": 2}
+	if !maps.Equal(added, expectedAdded) {
+		t.Errorf("actual added balls %v did not match expected %v", added, expectedAdded)
+	}
+	expected := map[string]int{"poke-ball": 20, "great-ball": 9, "ultra-ball": 2, "master-ball": 1}
+	if !maps.Equal(pokedex.Inventory, expected) {
+		t.Errorf("actual inventory %v did not match expected %v", pokedex.Inventory, expected)
+	}
+	if added := pokedex.Restock(); len(added) != 0 {
+		t.Errorf("restocking a full inventory added %v", added)
+	}
+	pokedex.UseBall("master-ball")
+	if added := pokedex.Restock(); added["master-ball"] != 0 || pokedex.BallCount("master-ball") != 0 {
+		t.Errorf("expected the master ball not to be restocked")
+	}
+	if count := NewPokeDex(t.TempDir()).BallCount("master-ball"); count != 1 {
+		t.Errorf("actual starter master-ball count %v did not match expected count 1", count)
+	}
+}
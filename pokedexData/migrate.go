@@ -0,0 +1,84 @@
+package pokedexData
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// SchemaVersion is the version of the save file written by Save. Changes
+// that older saves can't simply be decoded into, like renamed fields or new
+// fields that need a starting value, bump it and register a migration from
+// the version before.
+const SchemaVersion = 2
+
+var ErrNewerSave = errors.New("save is from a newer pokedex")
+
+type saveFile map[string]json.RawMessage
+
+type migration func(save saveFile) error
+
+// migrations[n] upgrades a version n save to version n+1.
+var migrations = map[int]migration{
+	0: migrateV0ToV1,
+	1: migrateV1ToV2,
+}
+
+// migrateV0ToV1 has nothing to move, version 0 saves are bare pokedexes from
+// before the version field was introduced.
+func migrateV0ToV1(save saveFile) error {
+	return nil
+}
+
+// migrateV1ToV2 hands trainers from before inventories the starter balls,
+// an empty inventory would leave them unable to catch anything.
+func migrateV1ToV2(save saveFile) error {
+	inventory, err := json.Marshal(StarterInventory())
+	if err != nil {
+		return err
+	}
+	save["inventory"] = inventory
+	return nil
+}
+
+func saveVersion(save saveFile) (int, error) {
+	rawVersion, ok := save["version"]
+	if !ok {
+		return 0, nil
+	}
+	var version int
+	if err := json.Unmarshal(rawVersion, &version); err != nil {
+		return 0, fmt.Errorf("bad save version %s: %w", rawVersion, err)
+	}
+	return version, nil
+}
+
+// migrate upgrades raw save data of any earlier schema version to the
+// current one.
+func migrate(data []byte) ([]byte, error) {
+	var save saveFile
+	if err := json.Unmarshal(data, &save); err != nil {
+		return nil, err
+	}
+	version, err := saveVersion(save)
+	if err != nil {
+		return nil, err
+	}
+	if version > SchemaVersion {
+		return nil, fmt.Errorf("%w: save is version %v but this pokedex only understands up to version %v", ErrNewerSave, version, SchemaVersion)
+	}
+	if version == SchemaVersion {
+		return data, nil
+	}
+	for ; version < SchemaVersion; version++ {
+		migrateVersion, ok := migrations[version]
+		if !ok {
+			return nil, fmt.Errorf("no migration from save version %v", version)
+		}
+		if err := migrateVersion(save); err != nil {
+			return nil, fmt.Errorf("migrate save from version %v: %w", version, err)
+		}
+		save["version"] = json.RawMessage(fmt.Sprint(version + 1))
+	}
+	return json.Marshal(save)
+}
@@ -0,0 +1,94 @@
+package pokedexData
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func loadFixture(t *testing.T, fixture string) (PokeDex, error) {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", fixture))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pokedex := NewPokeDex(t.TempDir())
+	if err := os.WriteFile(pokedex.Path(), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return pokedex, pokedex.Load()
+}
+
+func TestMigrationsRegistered(t *testing.T) {
+	for version := 0; version < SchemaVersion; version++ {
+		if _, ok := migrations[version]; !ok {
+			t.Errorf("no migration registered from version %v", version)
+		}
+	}
+}
+
+func TestLoadEveryVersion(t *testing.T) {
+	for version := 0; version <= SchemaVersion; version++ {
+		fixture := fmt.Sprintf("pokedex_v%v.json", version)
+		t.Run(fixture, func(t *testing.T) {
+			pokedex, err := loadFixture(t, fixture)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if pokedex.Version != SchemaVersion {
+				t.Errorf("version %v not migrated to %v", pokedex.Version, SchemaVersion)
+			}
+			pikachu, ok := pokedex.GetPokemon("pikachu")
+			if !ok {
+				t.Errorf("pikachu lost in migration")
+				return
+			}
+			if pikachu.CatchCount != 2 || pikachu.FailCatchCount != 1 {
+				t.Errorf("wrong catch counts %v/%v", pikachu.CatchCount, pikachu.FailCatchCount)
+			}
+			if pikachu.Description.Speed != 90 || pikachu.Description.Types[0] != "electric" {
+				t.Errorf("wrong description %v", pikachu.Description)
+			}
+			bulbasaur, ok := pokedex.GetPokemon("bulbasaur")
+			if !ok || bulbasaur.Description.Height != -1 || bulbasaur.FailCatchCount != 3 {
+				t.Errorf("wrong bulbasaur %v", bulbasaur)
+			}
+			expectedBalls := StarterInventory()["poke-ball"]
+			if version >= 2 {
+				expectedBalls = 7
+			}
+			if balls := pokedex.BallCount("poke-ball"); balls != expectedBalls {
+				t.Errorf("actual poke-ball count %v did not match expected count %v", balls, expectedBalls)
+			}
+			if err := pokedex.Save(); err != nil {
+				t.Error(err)
+				return
+			}
+			saved := NewPokeDex(filepath.Dir(pokedex.Path()))
+			if err := saved.Load(); err != nil || saved.Version != SchemaVersion {
+				t.Errorf("migrated save did not reload at version %v: %v", SchemaVersion, err)
+			}
+		})
+	}
+}
+
+func TestLoadNewerVersion(t *testing.T) {
+	pokedex := NewPokeDex(t.TempDir())
+	pokedex.Catch("pikachu", CatchEvent{Caught: true})
+	if err := pokedex.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if err := pokedex.Save(); err != nil {
+		t.Fatal(err)
+	}
+	save := fmt.Sprintf(`{"version":%v,"caught_pokemon":{}}`, SchemaVersion+1)
+	if err := os.WriteFile(pokedex.Path(), []byte(save), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := pokedex.Load(); !errors.Is(err, ErrNewerSave) {
+		t.Errorf("expected ErrNewerSave loading a save from a newer version, got %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+package pokedexData
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/asrioth/pokedexcli/pokeDirs"
+)
+
+const MaxBackups = 3
+
+var ErrRestoredFromBackup = errors.New("pokedex restored from backup")
+
+func backupPath(path string, generation int) string {
+	return fmt.Sprintf("%v.bak.%v", path, generation)
+}
+
+// Save writes the pokedex atomically after rotating the previous save into
+// a rolling set of backups, so a crash mid-write can never lose the
+// collection.
+func (P PokeDex) Save() error {
+	if P.path == "" {
+		return errors.New("pokedex has no save path")
+	}
+	if err := os.MkdirAll(filepath.Dir(P.path), 0755); err != nil {
+		return err
+	}
+	P.Version = SchemaVersion
+	data, err := json.Marshal(P)
+	if err != nil {
+		return err
+	}
+	if err := P.rotateBackups(); err != nil {
+		return fmt.Errorf("backup %v: %w", P.path, err)
+	}
+	return pokeDirs.WriteFileAtomic(P.path, data)
+}
+
+func (P PokeDex) rotateBackups() error {
+	current, err := os.ReadFile(P.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for generation := MaxBackups - 1; generation >= 1; generation-- {
+		err := os.Rename(backupPath(P.path, generation), backupPath(P.path, generation+1))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return pokeDirs.WriteFileAtomic(backupPath(P.path, 1), current)
+}
+
+// Load reads the saved pokedex, leaving it empty if nothing has been saved
+// yet. If the save is unreadable the newest readable backup is loaded
+// instead and ErrRestoredFromBackup is returned. A save from a newer pokedex
+// is never swapped for a backup, as saving over it would lose it.
+func (P *PokeDex) Load() error {
+	loadErr := P.loadFile(P.path)
+	if loadErr == nil || errors.Is(loadErr, fs.ErrNotExist) {
+		return nil
+	}
+	if errors.Is(loadErr, ErrNewerSave) {
+		return loadErr
+	}
+	for generation := 1; generation <= MaxBackups; generation++ {
+		backup := backupPath(P.path, generation)
+		if err := P.loadFile(backup); err == nil {
+			return fmt.Errorf("%w %v: %v", ErrRestoredFromBackup, backup, loadErr)
+		}
+	}
+	return loadErr
+}
+
+func (P *PokeDex) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	data, err = migrate(data)
+	if err != nil {
+		return fmt.Errorf("load %v: %w", path, err)
+	}
+	var pokedex PokeDex
+	if err := json.Unmarshal(data, &pokedex); err != nil {
+		return fmt.Errorf("load %v: %w", path, err)
+	}
+	if pokedex.CaughtPokemon == nil {
+		pokedex.CaughtPokemon = make(map[string]Pokemon)
+	}
+	if pokedex.Inventory == nil {
+		pokedex.Inventory = make(map[string]int)
+	}
+	pokedex.path = P.path
+	*P = pokedex
+	return nil
+}
@@ -0,0 +1,94 @@
+package pokedexData
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadMissingPokedex(t *testing.T) {
+	pokedex := NewPokeDex(t.TempDir())
+	if err := pokedex.Load(); err != nil {
+		t.Errorf("unexpected error loading a pokedex that was never saved: %v", err)
+	}
+	if pokedex.CaughtPokemon == nil {
+		t.Errorf("loading a missing pokedex left it without a map")
+	}
+}
+
+func TestSaveError(t *testing.T) {
+	dataDir := t.TempDir()
+	blocker := filepath.Join(dataDir, "blocker")
+	if err := os.WriteFile(blocker, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	pokedex := NewPokeDex(filepath.Join(blocker, "profile"))
+	if err := pokedex.Save(); err == nil {
+		t.Errorf("expected an error saving under a file")
+	}
+	if err := (PokeDex{}).Save(); err == nil {
+		t.Errorf("expected an error saving a pokedex without a path")
+	}
+}
+
+func TestSaveBackups(t *testing.T) {
+	dataDir := t.TempDir()
+	pokedex := NewPokeDex(dataDir)
+	names := []string{"bulbasaur", "ivysaur", "venusaur", "charmander", "charmeleon"}
+	for _, name := range names {
+		pokedex.Catch(name, CatchEvent{Caught: true})
+		if err := pokedex.Save(); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	for generation := 1; generation <= MaxBackups; generation++ {
+		backup := NewPokeDex(dataDir)
+		if err := backup.loadFile(backupPath(pokedex.Path(), generation)); err != nil {
+			t.Errorf("backup %v: %v", generation, err)
+			continue
+		}
+		if expected := len(names) - generation; len(backup.CaughtPokemon) != expected {
+			t.Errorf("backup %v has %v pokemon, expected %v", generation, len(backup.CaughtPokemon), expected)
+		}
+	}
+	if _, err := os.Stat(backupPath(pokedex.Path(), MaxBackups+1)); err == nil {
+		t.Errorf("more than %v backups kept", MaxBackups)
+	}
+	entries, _ := os.ReadDir(dataDir)
+	for _, entry := range entries {
+		if strings.Contains(entry.Name(), ".tmp") {
+			t.Errorf("temporary file %v left behind", entry.Name())
+		}
+	}
+}
+
+func TestLoadCorruptRestoresBackup(t *testing.T) {
+	dataDir := t.TempDir()
+	pokedex := NewPokeDex(dataDir)
+	pokedex.Catch("pikachu", CatchEvent{Caught: true})
+	pokedex.Save()
+	pokedex.Catch("raichu", CatchEvent{Caught: true})
+	pokedex.Save()
+	if err := os.WriteFile(pokedex.Path(), []byte(`{"caught_pokemon": {"pika`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	pokedex = NewPokeDex(dataDir)
+	err := pokedex.Load()
+	if !errors.Is(err, ErrRestoredFromBackup) {
+		t.Errorf("expected ErrRestoredFromBackup, got %v", err)
+	}
+	if _, ok := pokedex.GetPokemon("pikachu"); !ok {
+		t.Errorf("backup was not loaded")
+	}
+
+	for generation := 1; generation <= MaxBackups; generation++ {
+		os.Remove(backupPath(pokedex.Path(), generation))
+	}
+	pokedex = NewPokeDex(dataDir)
+	if err := pokedex.Load(); err == nil || errors.Is(err, ErrRestoredFromBackup) {
+		t.Errorf("expected a load error with no backups, got %v", err)
+	}
+}
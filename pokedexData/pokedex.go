@@ -1,82 +1,100 @@
 package pokedexData
 
 import (
-	"encoding/json"
-	"errors"
-	"io/fs"
-	"os"
-	"strings"
+	"path/filepath"
+	"sort"
+	"time"
 
 	"github.com/asrioth/pokedexcli/pokeapi"
 )
 
-const PokedexPath string = "pokedexData/pokedex.json"
+const PokedexFile string = "pokedex.json"
 
 type Pokemon struct {
 	Name           string                     `json:"name"`
 	Description    pokeapi.PokemonDescription `json:"description"`
 	CatchCount     int                        `json:"catch_count"`
 	FailCatchCount int                        `json:"fail_catch_count"`
+	History        []CatchEvent               `json:"history"`
+}
+
+type CatchEvent struct {
+	Time   time.Time `json:"time"`
+	Caught bool      `json:"caught"`
+	Area   string    `json:"area"`
+	Ball   string    `json:"ball"`
+	Roll   float64   `json:"roll"`
+	Level  int       `json:"level,omitempty"`
+}
+
+type PokemonCatchEvent struct {
+	Name string
+	CatchEvent
 }
 
 type PokeDex struct {
+	Version       int                `json:"version"`
 	CaughtPokemon map[string]Pokemon `json:"caught_pokemon"`
+	Inventory     map[string]int     `json:"inventory"`
+	Location      string             `json:"location,omitempty"`
+	path          string
 }
 
-func (P PokeDex) GetID() int {
-	return 0
+func NewPokeDex(dataDir string) PokeDex {
+	return PokeDex{CaughtPokemon: make(map[string]Pokemon), Inventory: StarterInventory(), path: filepath.Join(dataDir, PokedexFile)}
 }
 
-func (P PokeDex) GetName() string {
-	return ""
+func (P PokeDex) Path() string {
+	return P.path
 }
 
-func (P PokeDex) Save() {
-	_, err := os.Open(PokedexPath)
-	if errors.Is(err, fs.ErrNotExist) {
-		dirSep := strings.LastIndex(PokedexPath, "/")
-		os.MkdirAll(PokedexPath[:dirSep], 0755)
-	}
-
-	pokedexFile, err := os.Create(PokedexPath)
-	if err != nil {
-		return
-	}
-	defer pokedexFile.Close()
-	encoder := json.NewEncoder(pokedexFile)
-	if err := encoder.Encode(P); err != nil {
-		return
-	}
+func (P PokeDex) GetID() int {
+	return 0
 }
 
-func (P *PokeDex) Load() {
-	pokedexFile, err := os.Open(PokedexPath)
-	if err != nil {
-		return
-	}
-	defer pokedexFile.Close()
-	var pokedex PokeDex
-	decoder := json.NewDecoder(pokedexFile)
-	if err := decoder.Decode(&pokedex); err != nil {
-		return
-	}
-	*P = pokedex
+func (P PokeDex) GetName() string {
+	return ""
 }
 
-func (P PokeDex) Catch(name string, caught bool) Pokemon {
+func (P PokeDex) Catch(name string, event CatchEvent) Pokemon {
 	pokemon, ok := P.CaughtPokemon[name]
 	if !ok {
 		pokemon = Pokemon{Name: name, CatchCount: 0, FailCatchCount: 0, Description: pokeapi.PokemonDescription{Height: -1}}
 	}
-	if caught {
+	if event.Caught {
 		pokemon.CatchCount += 1
 	} else {
 		pokemon.FailCatchCount += 1
 	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	pokemon.History = append(pokemon.History, event)
 	P.CaughtPokemon[name] = pokemon
 	return pokemon
 }
 
+// CatchHistory lists every catch attempt, oldest first, for the named
+// pokemon or for all of them when name is empty.
+func (P PokeDex) CatchHistory(name string) []PokemonCatchEvent {
+	var history []PokemonCatchEvent
+	for _, pokemon := range P.CaughtPokemon {
+		if name != "" && pokemon.Name != name {
+			continue
+		}
+		for _, event := range pokemon.History {
+			history = append(history, PokemonCatchEvent{pokemon.Name, event})
+		}
+	}
+	sort.SliceStable(history, func(i, j int) bool {
+		if history[i].Time.Equal(history[j].Time) {
+			return history[i].Name < history[j].Name
+		}
+		return history[i].Time.Before(history[j].Time)
+	})
+	return history
+}
+
 func (P PokeDex) GetPokemon(name string) (Pokemon, bool) {
 	pokemon, ok := P.CaughtPokemon[name]
 	return pokemon, ok
@@ -1,18 +1,22 @@
 package pokedexData
 
-import "testing"
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
 
 func TestPokedexCatch(t *testing.T) {
 	pokedex := PokeDex{CaughtPokemon: make(map[string]Pokemon)}
 	name := "testachu"
-	pokedex.Catch(name, true)
+	pokedex.Catch(name, CatchEvent{Caught: true})
 	if pokedex.CaughtPokemon[name].CatchCount != 1 {
 		t.Errorf("Catch count (%v) not incremented on successful catch", pokedex.CaughtPokemon[name].CatchCount)
 	}
 	if pokedex.CaughtPokemon[name].FailCatchCount != 0 {
 		t.Errorf("Fail count (%v) incremented on successful catch when it shouldn't be", pokedex.CaughtPokemon[name].CatchCount)
 	}
-	pokedex.Catch(name, false)
+	pokedex.Catch(name, CatchEvent{Caught: false})
 	if pokedex.CaughtPokemon[name].CatchCount != 1 {
 		t.Errorf("Catch count (%v) incremented on failed catch when it shouldn't be", pokedex.CaughtPokemon[name].CatchCount)
 	}
@@ -22,11 +26,12 @@ func TestPokedexCatch(t *testing.T) {
 }
 
 func TestPokedexSaveLoad(t *testing.T) {
-	pokedex := PokeDex{CaughtPokemon: make(map[string]Pokemon)}
+	dataDir := t.TempDir()
+	pokedex := NewPokeDex(dataDir)
 	name := "testachu"
-	pokedex.Catch(name, true)
+	pokedex.Catch(name, CatchEvent{Caught: true})
 	pokedex.Save()
-	pokedex = PokeDex{}
+	pokedex = NewPokeDex(dataDir)
 	pokedex.Load()
 	pokemon, ok := pokedex.CaughtPokemon[name]
 	if !ok {
@@ -36,3 +41,61 @@ func TestPokedexSaveLoad(t *testing.T) {
 		t.Error("load incorrectly stored pokemon")
 	}
 }
+
+func TestCatchHistory(t *testing.T) {
+	pokedex := NewPokeDex(t.TempDir())
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	pokedex.Catch("pikachu", CatchEvent{Time: start.Add(2 * time.Minute), Caught: true, Area: "viridian-forest-area", Ball: "great-ball", Roll: 0.25})
+	pokedex.Catch("pidgey", CatchEvent{Time: start.Add(time.Minute), Caught: false, Area: "route-1-area", Ball: "poke-ball", Roll: 0.75})
+	pokedex.Catch("pikachu", CatchEvent{Time: start, Caught: false, Area: "viridian-forest-area", Ball: "poke-ball", Roll: 0.5})
+	pokedex.Catch("pidgey", CatchEvent{Caught: true})
+
+	history := pokedex.CatchHistory("")
+	if len(history) != 4 {
+		t.Errorf("expected 4 events, got %v", len(history))
+		return
+	}
+	expectedNames := []string{"pikachu", "pidgey", "pikachu", "pidgey"}
+	for index, event := range history {
+		if event.Name != expectedNames[index] {
+			t.Errorf("event %v is for %v, expected %v", index, event.Name, expectedNames[index])
+		}
+	}
+	if history[3].Time.IsZero() {
+		t.Errorf("catch without a time was not timestamped")
+	}
+
+	pikachuHistory := pokedex.CatchHistory("pikachu")
+	if len(pikachuHistory) != 2 || pikachuHistory[1].Ball != "great-ball" || !pikachuHistory[1].Caught {
+		t.Errorf("wrong pikachu history %v", pikachuHistory)
+	}
+
+	pokedex.Save()
+	loaded := NewPokeDex(filepath.Dir(pokedex.Path()))
+	if err := loaded.Load(); err != nil {
+		t.Error(err)
+		return
+	}
+	loadedHistory := loaded.CatchHistory("pikachu")
+	if len(loadedHistory) != 2 || loadedHistory[0].Area != "viridian-forest-area" || loadedHistory[0].Roll != 0.5 || !loadedHistory[0].Time.Equal(start) {
+		t.Errorf("history not saved, loaded %v", loadedHistory)
+	}
+}
+
+func TestLocationSaveLoad(t *testing.T) {
+	dataDir := t.TempDir()
+	pokedex := NewPokeDex(dataDir)
+	pokedex.Location = "eterna-city-area"
+	if err := pokedex.Save(); err != nil {
+		t.Error(err)
+		return
+	}
+	pokedex = NewPokeDex(dataDir)
+	if err := pokedex.Load(); err != nil {
+		t.Error(err)
+		return
+	}
+	if pokedex.Location != "eterna-city-area" {
+		t.Errorf("actual location '%v' did not match expected location 'eterna-city-area'", pokedex.Location)
+	}
+}
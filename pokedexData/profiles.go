@@ -0,0 +1,175 @@
+package pokedexData
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/asrioth/pokedexcli/pokeDirs"
+)
+
+const DefaultProfile = "default"
+const profilesDir = "profiles"
+const activeProfileFile = "active_profile"
+
+// LegacyPokedexPath is where the first releases saved the pokedex, relative
+// to the directory they were run from.
+const LegacyPokedexPath = "pokedexData/pokedex.json"
+
+var ErrLegacyPokedexKept = errors.New("old pokedex left in place")
+
+var profileNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+func profileDir(dataDir, profile string) string {
+	return filepath.Join(dataDir, profilesDir, profile)
+}
+
+func ValidateProfileName(profile string) error {
+	if !profileNamePattern.MatchString(profile) {
+		return fmt.Errorf("profile name %v must be letters, numbers, - or _", profile)
+	}
+	return nil
+}
+
+func ProfileExists(dataDir, profile string) bool {
+	info, err := os.Stat(profileDir(dataDir, profile))
+	return err == nil && info.IsDir()
+}
+
+func ListProfiles(dataDir string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(dataDir, profilesDir))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var profiles []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			profiles = append(profiles, entry.Name())
+		}
+	}
+	sort.Strings(profiles)
+	return profiles, nil
+}
+
+// LoadProfile loads the named profile's pokedex, creating the profile if it
+// doesn't exist yet.
+func LoadProfile(dataDir, profile string) (PokeDex, error) {
+	if err := ValidateProfileName(profile); err != nil {
+		return PokeDex{}, err
+	}
+	if err := os.MkdirAll(profileDir(dataDir, profile), 0755); err != nil {
+		return PokeDex{}, err
+	}
+	pokedex := NewPokeDex(profileDir(dataDir, profile))
+	return pokedex, pokedex.Load()
+}
+
+func CreateProfile(dataDir, profile string) error {
+	if err := ValidateProfileName(profile); err != nil {
+		return err
+	}
+	if ProfileExists(dataDir, profile) {
+		return fmt.Errorf("profile %v already exists", profile)
+	}
+	if err := os.MkdirAll(profileDir(dataDir, profile), 0755); err != nil {
+		return err
+	}
+	return NewPokeDex(profileDir(dataDir, profile)).Save()
+}
+
+func DeleteProfile(dataDir, profile string) error {
+	if err := ValidateProfileName(profile); err != nil {
+		return err
+	}
+	if !ProfileExists(dataDir, profile) {
+		return fmt.Errorf("no profile named %v", profile)
+	}
+	return os.RemoveAll(profileDir(dataDir, profile))
+}
+
+func ActiveProfile(dataDir string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(dataDir, activeProfileFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return DefaultProfile, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	profile := strings.TrimSpace(string(data))
+	if err := ValidateProfileName(profile); err != nil {
+		return DefaultProfile, nil
+	}
+	return profile, nil
+}
+
+func SetActiveProfile(dataDir, profile string) error {
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return err
+	}
+	return pokeDirs.WriteFileAtomic(filepath.Join(dataDir, activeProfileFile), []byte(profile+"\n"))
+}
+
+// MigrateSingleProfile moves a pokedex saved before profiles existed, along
+// with its backups, into the default profile.
+func MigrateSingleProfile(dataDir string) error {
+	legacyPath := filepath.Join(dataDir, PokedexFile)
+	if _, err := os.Stat(legacyPath); err != nil {
+		return nil
+	}
+	if ProfileExists(dataDir, DefaultProfile) {
+		return nil
+	}
+	return moveIntoDefaultProfile(dataDir, legacyPath)
+}
+
+// MigrateLegacyPokedex moves a pokedex saved at legacyPath by the first
+// releases into the default profile. One already in the default profile is
+// never overwritten, ErrLegacyPokedexKept says the old one is still there.
+func MigrateLegacyPokedex(dataDir, legacyPath string) error {
+	if _, err := os.Stat(legacyPath); err != nil {
+		return nil
+	}
+	defaultPath := filepath.Join(profileDir(dataDir, DefaultProfile), PokedexFile)
+	if _, err := os.Stat(defaultPath); err == nil {
+		return fmt.Errorf("%w: %v was not moved, %v already exists", ErrLegacyPokedexKept, legacyPath, defaultPath)
+	}
+	return moveIntoDefaultProfile(dataDir, legacyPath)
+}
+
+func moveIntoDefaultProfile(dataDir, legacyPath string) error {
+	defaultDir := profileDir(dataDir, DefaultProfile)
+	if err := os.MkdirAll(defaultDir, 0755); err != nil {
+		return err
+	}
+	for generation := MaxBackups; generation >= 1; generation-- {
+		err := moveFile(backupPath(legacyPath, generation), backupPath(filepath.Join(defaultDir, PokedexFile), generation))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return moveFile(legacyPath, filepath.Join(defaultDir, PokedexFile))
+}
+
+// moveFile copies when renaming fails, as the old save is usually on a
+// different filesystem to the data dir.
+func moveFile(from, to string) error {
+	if err := os.Rename(from, to); err == nil {
+		return nil
+	}
+	data, err := os.ReadFile(from)
+	if err != nil {
+		return err
+	}
+	if err := pokeDirs.WriteFileAtomic(to, data); err != nil {
+		return err
+	}
+	return os.Remove(from)
+}
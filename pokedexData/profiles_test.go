@@ -0,0 +1,137 @@
+package pokedexData
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProfiles(t *testing.T) {
+	dataDir := t.TempDir()
+	profile, err := ActiveProfile(dataDir)
+	if err != nil || profile != DefaultProfile {
+		t.Errorf("expected the default profile to start active, got %v %v", profile, err)
+	}
+	for _, name := range []string{"ash", "misty"} {
+		if err := CreateProfile(dataDir, name); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	if err := CreateProfile(dataDir, "ash"); err == nil {
+		t.Errorf("expected an error creating a profile twice")
+	}
+	if err := CreateProfile(dataDir, "../brock"); err == nil {
+		t.Errorf("expected an error creating a profile outside the profiles directory")
+	}
+
+	ash, err := LoadProfile(dataDir, "ash")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	ash.Catch("pikachu", CatchEvent{Caught: true})
+	ash.Save()
+	misty, err := LoadProfile(dataDir, "misty")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if _, ok := misty.GetPokemon("pikachu"); ok {
+		t.Errorf("profiles share a pokedex")
+	}
+
+	if err := SetActiveProfile(dataDir, "misty"); err != nil {
+		t.Error(err)
+		return
+	}
+	if profile, _ := ActiveProfile(dataDir); profile != "misty" {
+		t.Errorf("active profile %v, expected misty", profile)
+	}
+
+	profiles, err := ListProfiles(dataDir)
+	if err != nil || len(profiles) != 2 || profiles[0] != "ash" || profiles[1] != "misty" {
+		t.Errorf("listed profiles %v %v, expected [ash misty]", profiles, err)
+	}
+	if err := DeleteProfile(dataDir, "ash"); err != nil {
+		t.Error(err)
+	}
+	if ProfileExists(dataDir, "ash") {
+		t.Errorf("deleted profile still exists")
+	}
+	if err := DeleteProfile(dataDir, "ash"); err == nil {
+		t.Errorf("expected an error deleting a missing profile")
+	}
+}
+
+func TestMigrateSingleProfile(t *testing.T) {
+	dataDir := t.TempDir()
+	pokedex := NewPokeDex(dataDir)
+	pokedex.Catch("pikachu", CatchEvent{Caught: true})
+	pokedex.Save()
+	pokedex.Catch("raichu", CatchEvent{Caught: true})
+	pokedex.Save()
+	if err := MigrateSingleProfile(dataDir); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err := os.Stat(filepath.Join(dataDir, PokedexFile)); err == nil {
+		t.Errorf("old pokedex left in place")
+	}
+	migrated, err := LoadProfile(dataDir, DefaultProfile)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if _, ok := migrated.GetPokemon("raichu"); !ok {
+		t.Errorf("pokedex not moved into the default profile")
+	}
+	if _, err := os.Stat(backupPath(migrated.Path(), 1)); err != nil {
+		t.Errorf("backup not moved into the default profile: %v", err)
+	}
+}
+
+func TestMigrateLegacyPokedex(t *testing.T) {
+	dataDir := t.TempDir()
+	legacyDir := t.TempDir()
+	legacyPath := filepath.Join(legacyDir, PokedexFile)
+	if err := os.WriteFile(legacyPath, []byte(`{"caught_pokemon":{"pikachu":{"name":"pikachu","catch_count":1}}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := MigrateLegacyPokedex(dataDir, legacyPath); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err := os.Stat(legacyPath); err == nil {
+		t.Errorf("old pokedex left in place")
+	}
+	migrated, err := LoadProfile(dataDir, DefaultProfile)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if _, ok := migrated.GetPokemon("pikachu"); !ok {
+		t.Errorf("pokedex not moved into the default profile")
+	}
+}
+
+func TestMigrateLegacyPokedexKept(t *testing.T) {
+	dataDir := t.TempDir()
+	legacyPath := filepath.Join(t.TempDir(), PokedexFile)
+	if err := os.WriteFile(legacyPath, []byte(`{"caught_pokemon":{}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := CreateProfile(dataDir, DefaultProfile); err != nil {
+		t.Fatal(err)
+	}
+	if err := MigrateLegacyPokedex(dataDir, legacyPath); !errors.Is(err, ErrLegacyPokedexKept) {
+		t.Errorf("expected ErrLegacyPokedexKept, got %v", err)
+	}
+	if _, err := os.Stat(legacyPath); err != nil {
+		t.Errorf("old pokedex not left in place: %v", err)
+	}
+	if err := MigrateLegacyPokedex(dataDir, filepath.Join(t.TempDir(), PokedexFile)); err != nil {
+		t.Errorf("unexpected error with no old pokedex: %v", err)
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/asrioth/pokedexcli/pokedexData"
+)
+
+func commandProfile(ctx context.Context, config *Config) error {
+	subcommand := config.args[0]
+	if subcommand == "list" {
+		return listProfiles(config)
+	}
+	if len(config.args) < 2 {
+		return fmt.Errorf("profile %v expects a profile name", subcommand)
+	}
+	name := config.args[1]
+	switch subcommand {
+	case "new":
+		if err := pokedexData.CreateProfile(config.dataDir, name); err != nil {
+			return err
+		}
+		fmt.Printf("Created profile %v, use profile switch %v to play as them\n", name, name)
+		return nil
+	case "switch":
+		return switchProfile(config, name)
+	case "delete":
+		if name == config.profile {
+			return errors.New("can't delete the active profile, switch to another one first")
+		}
+		if err := pokedexData.DeleteProfile(config.dataDir, name); err != nil {
+			return err
+		}
+		fmt.Printf("Deleted profile %v\n", name)
+		return nil
+	}
+	return fmt.Errorf("unknown profile command %v, expected list, new, switch or delete", subcommand)
+}
+
+func listProfiles(config *Config) error {
+	profiles, err := pokedexData.ListProfiles(config.dataDir)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Profiles:")
+	for _, profile := range profiles {
+		if profile == config.profile {
+			printBullet(profile, " (active)")
+		} else {
+			printBullet("", profile)
+		}
+	}
+	return nil
+}
+
+func switchProfile(config *Config, name string) error {
+	if !pokedexData.ProfileExists(config.dataDir, name) {
+		return fmt.Errorf("no profile named %v, create it with profile new %v", name, name)
+	}
+	pokedex, err := pokedexData.LoadProfile(config.dataDir, name)
+	if errors.Is(err, pokedexData.ErrRestoredFromBackup) {
+		fmt.Printf("warning: %v\n", err)
+	} else if err != nil {
+		return err
+	}
+	if err := pokedexData.SetActiveProfile(config.dataDir, name); err != nil {
+		return err
+	}
+	config.pokedex = &pokedex
+	config.profile = name
+	config.area = ""
+	config.areaPokemon = nil
+	config.wild = nil
+	fmt.Printf("Switched to profile %v\n", name)
+	return nil
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestRunCommandsArgs(t *testing.T) {
+	var calls [][]string
+	record := func(ctx context.Context, config *Config) error {
+		calls = append(calls, slices.Clone(config.args))
+		return nil
+	}
+	supportedCommands := map[string]CliCommand{
+		"map":     {name: "map", callback: record},
+		"explore": {name: "explore", callback: record, minArgs: 1, maxArgs: 1},
+		"profile": {name: "profile", callback: record, minArgs: 1, maxArgs: 2},
+	}
+	cases := []struct {
+		input    string
+		expected [][]string
+	}{
+		{input: "map", expected: [][]string{{}}},
+		{input: "explore eterna-city-area map", expected: [][]string{{"eterna-city-area"}, {}}},
+		{input: "profile list map", expected: [][]string{{"list"}, {}}},
+		{input: "profile switch ash", expected: [][]string{{"switch", "ash"}}},
+		{input: "explore map", expected: [][]string{{"map"}}},
+		{input: "explore", expected: nil},
+		{input: "map bogus", expected: [][]string{{}}},
+	}
+	for _, c := range cases {
+		calls = nil
+		runCommands(context.Background(), cleanInput(c.input), supportedCommands, &Config{})
+		if len(calls) != len(c.expected) {
+			t.Errorf("input '%v': %v calls, expected %v", c.input, len(calls), len(c.expected))
+			continue
+		}
+		for index := range calls {
+			if !slices.Equal(calls[index], c.expected[index]) && len(calls[index])+len(c.expected[index]) > 0 {
+				t.Errorf("input '%v': call %v got args %v, expected %v", c.input, index, calls[index], c.expected[index])
+			}
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/asrioth/pokedexcli/pokeapi"
+)
+
+func commandSync(ctx context.Context, config *Config) error {
+	if config.client.Offline {
+		return errors.New("can't sync while offline, restart without -offline")
+	}
+	resources := config.args
+	if len(resources) == 0 {
+		resources = pokeapi.SyncResources
+	}
+	for _, resource := range resources {
+		err := config.client.Sync(ctx, resource, func(resource string, done, total int) {
+			fmt.Printf("\rSyncing %v: %v/%v", resource, done, total)
+		})
+		fmt.Println()
+		if err != nil {
+			return fmt.Errorf("sync %v stopped, run sync again to resume: %w", resource, err)
+		}
+	}
+	fmt.Println("Sync complete, everything synced can be used with -offline")
+	return nil
+}
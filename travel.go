@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+func commandTravel(ctx context.Context, config *Config) error {
+	area := config.args[0]
+	if _, err := enterArea(ctx, config, area); err != nil {
+		return err
+	}
+	config.pokedex.Location = area
+	fmt.Printf("Travelled to %v\n", area)
+	if err := config.pokedex.Save(); err != nil {
+		return fmt.Errorf("could not save your pokedex: %w", err)
+	}
+	return nil
+}
+
+func commandWhere(ctx context.Context, config *Config) error {
+	if config.pokedex.Location == "" {
+		return errors.New("you haven't travelled anywhere yet, try travel <area>")
+	}
+	fmt.Printf("You are in %v\n", config.pokedex.Location)
+	return nil
+}
+
+// returnToLocation re-enters the area the pokedex was saved in, which a
+// restart or profile switch leaves unexplored.
+func returnToLocation(ctx context.Context, config *Config) error {
+	if config.area != "" || config.pokedex.Location == "" {
+		return nil
+	}
+	_, err := enterArea(ctx, config, config.pokedex.Location)
+	return err
+}
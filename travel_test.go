@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/asrioth/pokedexcli/pokeapi"
+	"github.com/asrioth/pokedexcli/pokedexData"
+)
+
+func TestReturnToLocation(t *testing.T) {
+	client := pokeapi.NewClient("", 0)
+	client.Offline = true
+	client.Cache = pokeapi.NewMemoryCache(nil, pokeapi.DefaultMemoryEntries)
+	area := `{"id":1,"name":"eterna-city-area","pokemon_encounters":[{"pokemon":{"name":"psyduck"},"version_details":[{"version":{"name":"diamond"}}]}]}`
+	if err := client.Cache.Put("location-area", 1, "eterna-city-area", []byte(area)); err != nil {
+		t.Fatal(err)
+	}
+	pokedex := pokedexData.NewPokeDex(t.TempDir())
+	pokedex.Location = "eterna-city-area"
+	config := &Config{client: client, pokedex: &pokedex}
+	if err := returnToLocation(context.Background(), config); err != nil {
+		t.Error(err)
+		return
+	}
+	if config.area != "eterna-city-area" || !slices.Equal(config.areaPokemon, []string{"psyduck"}) {
+		t.Errorf("actual area %v with %v did not match the saved location", config.area, config.areaPokemon)
+	}
+	if err := catchable(config, "psyduck"); err != nil {
+		t.Error(err)
+	}
+}
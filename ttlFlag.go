@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/asrioth/pokedexcli/pokeapi"
+)
+
+// ttlFlag collects repeated -ttl resource=duration flags.
+type ttlFlag map[string]time.Duration
+
+func (T ttlFlag) String() string {
+	var ttls []string
+	for resource, ttl := range T {
+		ttls = append(ttls, fmt.Sprintf("%v=%v", resource, ttl))
+	}
+	sort.Strings(ttls)
+	return strings.Join(ttls, ",")
+}
+
+func (T ttlFlag) Set(value string) error {
+	resource, rawTtl, ok := strings.Cut(value, "=")
+	if !ok || resource == "" {
+		return fmt.Errorf("ttl %v should be resource=duration eg. location-area=24h", value)
+	}
+	if !pokeapi.IsCachedResource(resource) {
+		return fmt.Errorf("unknown resource %v, try one of %v or their -list", resource, strings.Join(pokeapi.CachedResources, ", "))
+	}
+	ttl, err := time.ParseDuration(rawTtl)
+	if err != nil {
+		return err
+	}
+	if ttl < 0 {
+		return fmt.Errorf("ttl for %v can't be negative", resource)
+	}
+	T[resource] = ttl
+	return nil
+}
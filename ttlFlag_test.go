@@ -0,0 +1,30 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTtlFlag(t *testing.T) {
+	ttls := ttlFlag{}
+	if err := ttls.Set("location-area=24h"); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := ttls.Set("pokemon=0s"); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := ttls.Set("pokemon-list=1h"); err != nil {
+		t.Error(err)
+		return
+	}
+	if ttls["location-area"] != 24*time.Hour || ttls["pokemon"] != 0 {
+		t.Errorf("actual ttls %v did not match expected ttls location-area=24h0m0s,pokemon=0s", ttls)
+	}
+	for _, bad := range []string{"pokemon", "=1h", "pokemon=soon", "pokmon=1h", "berry-list=1h", "pokemon=-1h"} {
+		if err := ttls.Set(bad); err == nil {
+			t.Errorf("expected an error for ttl '%v'", bad)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+func commandVersion(ctx context.Context, config *Config) error {
+	if len(config.args) == 0 {
+		if config.version == "" {
+			fmt.Println("Showing pokemon from every game version")
+		} else {
+			fmt.Printf("Showing pokemon from %v\n", config.version)
+		}
+		return nil
+	}
+	if config.args[0] == "all" {
+		if err := setVersion(ctx, config, ""); err != nil {
+			return err
+		}
+		fmt.Println("Showing pokemon from every game version")
+		return nil
+	}
+	version, err := config.client.GetVersion(ctx, config.args[0])
+	if err != nil {
+		return err
+	}
+	if err := setVersion(ctx, config, version.Name); err != nil {
+		return err
+	}
+	fmt.Printf("Showing pokemon from %v\n", config.version)
+	return nil
+}
+
+// setVersion re-enters the current area, so the pokemon that can be caught
+// there follow the new version too.
+func setVersion(ctx context.Context, config *Config, version string) error {
+	config.version = version
+	if config.area == "" {
+		return nil
+	}
+	_, err := enterArea(ctx, config, config.area)
+	return err
+}
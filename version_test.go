@@ -0,0 +1,31 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/asrioth/pokedexcli/pokeapi"
+)
+
+func TestVersionRefiltersArea(t *testing.T) {
+	client := pokeapi.NewClient("", 0)
+	client.Offline = true
+	client.Cache = pokeapi.NewMemoryCache(nil, pokeapi.DefaultMemoryEntries)
+	area := `{"id":2,"name":"eterna-city-area","pokemon_encounters":[` +
+		`{"pokemon":{"name":"psyduck"},"version_details":[{"version":{"name":"diamond"}},{"version":{"name":"pearl"}},{"version":{"name":"platinum"}}]},` +
+		`{"pokemon":{"name":"golduck"},"version_details":[{"version":{"name":"diamond"}},{"version":{"name":"pearl"}}]}]}`
+	client.Cache.Put("location-area", 2, "eterna-city-area", []byte(area))
+	client.Cache.Put("version", 13, "pearl", []byte(`{"id":13,"name":"pearl"}`))
+	client.Cache.Put("version", 14, "platinum", []byte(`{"id":14,"name":"platinum"}`))
+	commands := initializeCommands()
+	config := &Config{client: client}
+	runCommands(context.Background(), cleanInput("explore eterna-city-area version platinum"), commands, config)
+	if err := catchable(config, "golduck"); err == nil {
+		t.Errorf("expected golduck to be uncatchable in platinum")
+	}
+	config = &Config{client: client}
+	runCommands(context.Background(), cleanInput("version pearl explore eterna-city-area version all"), commands, config)
+	if err := catchable(config, "golduck"); err != nil {
+		t.Errorf("expected golduck to be catchable in every version: %v", err)
+	}
+}